@@ -1,23 +1,41 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/the-maldridge/vInstaller/internal/config"
 	"github.com/the-maldridge/vInstaller/internal/frontend"
+	_ "github.com/the-maldridge/vInstaller/internal/frontend/jsonrpc"
 	_ "github.com/the-maldridge/vInstaller/internal/frontend/prompt"
 	_ "github.com/the-maldridge/vInstaller/internal/frontend/test"
+	_ "github.com/the-maldridge/vInstaller/internal/frontend/tui"
+	_ "github.com/the-maldridge/vInstaller/internal/frontend/web"
 
+	"github.com/the-maldridge/vInstaller/internal/i18n"
 	"github.com/the-maldridge/vInstaller/internal/installer"
 )
 
 var (
 	targetDir = flag.String("target", "/target", "Mountpoint for the target filesystem")
+	resume    = flag.Bool("resume", false, "Resume an interrupted installation from the journal in the target")
+	rollback  = flag.Bool("rollback", true, "Revert the configuration of the target if the installation fails")
+	templates = flag.String("templates", "", "Directory of templates that override the built in ones")
+	dryRun    = flag.Bool("dry-run", false, "Show what would be done to the disks without changing anything")
 )
 
 func main() {
 	flag.Parse()
+	if frontend.Listing() {
+		frontend.PrintList(os.Stdout)
+		return
+	}
 	log.Println("Welcome to the installer!")
+	log.Printf("Messages will be shown in %s", i18n.Language())
 
 	f, err := frontend.New()
 	if err != nil {
@@ -26,25 +44,54 @@ func main() {
 
 	cfg, err := f.GetInstallerConfig()
 	if err != nil {
-		log.Println(err)
+		log.Fatal(err)
+	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal("Invalid configuration: ", err)
 	}
 
 	if err := f.ConfirmInstallation(); err != nil {
 		log.Fatal(err)
 	}
 
-	output := make(chan string, 50)
+	output := make(chan i18n.Message, 50)
 	errors := make(chan error, 10)
 	done := make(chan bool)
 
+	meta := config.DefaultMeta()
+	meta.TemplateDir = *templates
+
 	installer := &installer.Installer{
 		Config: cfg,
+		Meta:   meta,
 		Output: output,
 		Errors: errors,
 		Done:   done,
+
+		Resume:   *resume,
+		Rollback: *rollback,
+		DryRun:   *dryRun,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if c, ok := f.(frontend.Canceller); ok {
+		c.SetCancel(cancel)
 	}
 
-	go installer.Install(*targetDir)
+	// Interrupting the installer cancels the installation rather
+	// than killing it outright so that the target gets cleaned
+	// up.
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		for s := range sigs {
+			log.Printf("Received %s, cancelling installation", s)
+			cancel()
+		}
+	}()
+
+	go installer.Install(ctx, *targetDir)
 
 	f.ShowInstallationProgress(output, errors, done)
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"os"
+	"sort"
 	"strings"
 )
 
@@ -10,41 +12,257 @@ type Config struct {
 	TimeZone string
 	Locale   string
 	Keyboard string
+
+	// Hostname is the short name of the system, Domain is added
+	// to it for the fully qualified name.  A Hostname with dots
+	// in it is split into the two if there is no Domain.
 	Hostname string
+	Domain   string
+
+	// Hosts are additional static entries for /etc/hosts.
+	Hosts []Host
+
+	// HardwareClock is either UTC or localtime, the latter is
+	// useful when dual booting.
+	HardwareClock string
+
+	// Font, FontMap and FontUnimap are the console font
+	// settings, see setfont(8).
+	Font       string
+	FontMap    string
+	FontUnimap string
+
+	// TTYS is the number of ttys to set up.
+	TTYS int
+
+	// RCVars are additional variables to set in rc.conf.
+	RCVars map[string]string
 
-	RootPassword string
+	// The root password may be given in the clear or already
+	// hashed, as for users.  LockRoot disables password logins as
+	// root, which is only allowed if a user is in the wheel group.
+	RootPassword     string
+	RootPasswordHash string
+	LockRoot         bool
+	RootShell        string
 
 	Users []User
 
+	// CreateGroups creates groups users are to be added to that
+	// don't exist in the target, rather than skipping them.
+	CreateGroups bool
+
+	// Privilege is how users become root.
+	Privilege Privilege
+
+	// Network is how the installed system gets online.
+	Network Network
+
+	// Services adjust the runit services the installer enables
+	// by default, which are those from Meta and those networking
+	// needs.
+	Services []Service
+
 	GRUB struct {
 		UseGraphical bool
 		InstallTo    string
 	}
 
+	// Layout has a disk partitioned and formatted by the
+	// installer, Filesystems are used as they are unless marked
+	// to be formatted.
+	Layout      Layout
 	Filesystems []Filesystem
+
+	// Packages are installed in addition to base-system.
+	Packages []string
+
+	Files []File
+}
+
+// A Group is one of the groups that users on Void commonly need.
+type Group struct {
+	Name        string
+	Description string
 }
 
-// User represents a system user
+// CommonGroups are offered to users of the interactive frontends.
+var CommonGroups = []Group{
+	{"wheel", "can become root with sudo or doas"},
+	{"audio", "can use sound devices directly"},
+	{"video", "can use video devices and GPU acceleration"},
+	{"network", "can manage network connections"},
+	{"plugdev", "can mount removable devices"},
+	{"input", "can read input devices directly"},
+}
+
+// User represents a system user.  The password may be given in the
+// clear, or already hashed as it would appear in /etc/shadow.  A hash
+// takes precedence.  Lock locks the password, leaving SSH keys as
+// the way to log in.
 type User struct {
-	Username string
-	GECOS    string
-	Password string
-	Groups   []string
+	Username     string
+	GECOS        string
+	Password     string
+	PasswordHash string
+	Lock         bool
+	Groups       []string
+
+	// SSHKeys are written to the user's authorized_keys, along
+	// with the keys in SSHKeyFiles, which are read from the live
+	// system.
+	SSHKeys     []string
+	SSHKeyFiles []string
+
+	// Shell, UID, GID and Home are left to useradd's defaults
+	// when unset.  If GID is set and the group doesn't exist, a
+	// group named after the user is created with it.
+	Shell string
+	UID   int
+	GID   int
+	Home  string
+
+	// NoCreateHome skips creating the home directory, System
+	// makes a system account.
+	NoCreateHome bool
+	System       bool
 }
 
 func (c Config) String() string {
 	out := []string{"Your system configuration is as follows:"}
 
-	out = append(out, fmt.Sprintf("Hostname: %s", c.Hostname))
+	out = append(out, fmt.Sprintf("Hostname: %s", c.FQDN()))
+	for _, h := range c.Hosts {
+		out = append(out, fmt.Sprintf("  Host: %s %s", h.IP, strings.Join(h.Names, " ")))
+	}
+	if c.Layout.Disk != "" {
+		out = append(out, fmt.Sprintf("Disk: %s, erased and laid out as %s", c.Layout.Disk, c.Layout.Scheme))
+	}
+	for _, f := range c.Filesystems {
+		line := fmt.Sprintf("Filesystem: %s on %s", f.FS, f.MountTo)
+		if f.Format {
+			line += fmt.Sprintf(", formatted as %s", f.Type)
+		}
+		out = append(out, line)
+	}
+	if c.GRUB.InstallTo != "" {
+		out = append(out, fmt.Sprintf("Bootloader: GRUB on %s", c.GRUB.InstallTo))
+	}
 	out = append(out, fmt.Sprintf("Keyboard: %s", c.Keyboard))
 	out = append(out, fmt.Sprintf("Timezone: %s", c.TimeZone))
 	out = append(out, fmt.Sprintf("Locale: %s", c.Locale))
+	if len(c.Packages) > 0 {
+		out = append(out, fmt.Sprintf("Packages: %s", strings.Join(c.Packages, " ")))
+	}
+	if c.HardwareClock != "" {
+		out = append(out, fmt.Sprintf("Hardware clock: %s", c.HardwareClock))
+	}
+	if c.Font != "" {
+		out = append(out, fmt.Sprintf("Console font: %s", c.Font))
+	}
+	if c.FontMap != "" {
+		out = append(out, fmt.Sprintf("Console map: %s", c.FontMap))
+	}
+	if c.FontUnimap != "" {
+		out = append(out, fmt.Sprintf("Console unimap: %s", c.FontUnimap))
+	}
+	if c.TTYS != 0 {
+		out = append(out, fmt.Sprintf("TTYs: %d", c.TTYS))
+	}
+	vars := []string{}
+	for k := range c.RCVars {
+		vars = append(vars, k)
+	}
+	sort.Strings(vars)
+	for _, k := range vars {
+		out = append(out, fmt.Sprintf("rc.conf: %s=%s", k, c.RCVars[k]))
+	}
+
+	switch {
+	case c.LockRoot:
+		out = append(out, "Root: locked")
+	case c.RootPassword != "" || c.RootPasswordHash != "":
+		out = append(out, "Root: password set")
+	default:
+		out = append(out, "Root: password unchanged")
+	}
+	if c.RootShell != "" {
+		out = append(out, fmt.Sprintf("Root shell: %s", c.RootShell))
+	}
+
+	out = append(out, fmt.Sprintf("Network: %s", c.NetworkManager()))
+	for _, n := range c.Network.Interfaces {
+		addrs := "DHCP"
+		if len(n.Addresses) > 0 {
+			addrs = strings.Join(n.Addresses, " ")
+			if n.Gateway != "" {
+				addrs += " via " + n.Gateway
+			}
+		}
+		out = append(out, fmt.Sprintf("  %s: %s", n.Name, addrs))
+	}
+	if len(c.Network.DNS) > 0 {
+		out = append(out, fmt.Sprintf("  DNS: %s", strings.Join(c.Network.DNS, " ")))
+	}
+	for _, w := range c.Network.Wifi {
+		out = append(out, fmt.Sprintf("  Wifi: %s", w.SSID))
+	}
+
+	for _, s := range c.Services {
+		state := "enabled"
+		switch {
+		case s.Disable:
+			state = "disabled"
+		case s.Down:
+			state = "enabled, not started"
+		}
+		if s.Runsvdir != "" {
+			state += " in " + s.Runsvdir
+		}
+		out = append(out, fmt.Sprintf("Service %s: %s", s.Name, state))
+	}
+
+	if c.HasWheelUser() || len(c.Privilege.Rules) > 0 {
+		priv := c.PrivilegeTool()
+		if c.Privilege.NoPassword {
+			priv += ", no password for wheel"
+		}
+		out = append(out, fmt.Sprintf("Privileges: %s", priv))
+		for _, r := range c.Privilege.Rules {
+			cmds := "anything"
+			if len(r.Commands) > 0 {
+				cmds = strings.Join(r.Commands, ", ")
+			}
+			out = append(out, fmt.Sprintf("  %s may run %s", r.User, cmds))
+		}
+	}
 
 	for i, u := range c.Users {
 		out = append(out, fmt.Sprintf("User 100%d", i))
 		out = append(out, fmt.Sprintf("  Username: %s", u.Username))
 		out = append(out, fmt.Sprintf("  Name: %s", u.GECOS))
 		out = append(out, fmt.Sprintf("  Groups: %s", strings.Join(u.Groups, ",")))
+		if u.Lock {
+			out = append(out, "  Password: locked")
+		}
+		if u.Shell != "" {
+			out = append(out, fmt.Sprintf("  Shell: %s", u.Shell))
+		}
+		if u.UID != 0 || u.GID != 0 {
+			out = append(out, fmt.Sprintf("  UID/GID: %d/%d", u.UID, u.GID))
+		}
+		if u.Home != "" {
+			out = append(out, fmt.Sprintf("  Home: %s", u.Home))
+		}
+		if u.NoCreateHome {
+			out = append(out, "  Home directory will not be created")
+		}
+		if u.System {
+			out = append(out, "  System account")
+		}
+		if n := len(u.SSHKeys) + len(u.SSHKeyFiles); n > 0 {
+			out = append(out, fmt.Sprintf("  SSH keys: %d inline, %d from files", len(u.SSHKeys), len(u.SSHKeyFiles)))
+		}
 	}
 
 	return strings.Join(out, "\n")
@@ -55,6 +273,10 @@ func (c Config) String() string {
 type Meta struct {
 	Mirror   string
 	Services []string
+
+	// TemplateDir is searched for templates before the built in
+	// ones are used.
+	TemplateDir string
 }
 
 // DefaultMeta returns the default metadata which should be safe to use
@@ -66,7 +288,8 @@ func DefaultMeta() *Meta {
 }
 
 // Filesystem represents a filesystem that is ready to go into the
-// installer, and can be mapped onto /etc/fstab.
+// installer, and can be mapped onto /etc/fstab.  Format creates the
+// filesystem first, destroying whatever is on FS.
 type Filesystem struct {
 	FS      string
 	MountTo string
@@ -74,4 +297,162 @@ type Filesystem struct {
 	Options string
 	Dump    int
 	Pass    int
+	Format  bool
+}
+
+// Layout is a guided layout of a whole disk, everything on which is
+// erased.  Scheme is ext4, btrfs, or luks for ext4 on LVM inside an
+// encrypted partition, which is opened with Passphrase.
+type Layout struct {
+	Disk       string
+	Scheme     string
+	Passphrase string
+}
+
+// Service is a runit service to enable or disable.
+type Service struct {
+	Name string
+
+	// Runsvdir is the runlevel the service is linked into,
+	// default if unset.  It is created if it doesn't exist.
+	Runsvdir string
+
+	// Down enables the service without starting it, it can then
+	// be started with sv up.  This applies to every runsvdir.
+	// Without it any down file the service has is removed.
+	Down bool
+
+	// Disable removes the service from the runsvdir, such as one
+	// that base-system enables.
+	Disable bool
+}
+
+// Host is a static entry in /etc/hosts.
+type Host struct {
+	IP    string
+	Names []string
+}
+
+// ShortHostname returns the first label of the hostname, which is
+// what goes into /etc/hostname.
+func (c Config) ShortHostname() string {
+	return strings.SplitN(c.Hostname, ".", 2)[0]
+}
+
+// DomainName returns the domain the system is in, if any.
+func (c Config) DomainName() string {
+	if c.Domain != "" {
+		return strings.TrimSuffix(c.Domain, ".")
+	}
+	parts := strings.SplitN(c.Hostname, ".", 2)
+	if len(parts) == 2 {
+		return strings.TrimSuffix(parts[1], ".")
+	}
+	return ""
+}
+
+// FQDN returns the fully qualified name of the system, which is the
+// short name if there is no domain.
+func (c Config) FQDN() string {
+	if d := c.DomainName(); d != "" {
+		return c.ShortHostname() + "." + d
+	}
+	return c.ShortHostname()
+}
+
+// File is an additional file to be rendered from a template into the
+// target.  The mode defaults to 0644.
+type File struct {
+	Template string
+	Path     string
+	Mode     os.FileMode
+}
+
+// Privilege configures sudo or doas.  Members of the wheel group may
+// run anything as root, Rules grant individual users more or less
+// than that.
+type Privilege struct {
+	// Tool is either sudo or doas, sudo is used if unset.
+	Tool string
+
+	// NoPassword lets the wheel group become root without
+	// entering a password.
+	NoPassword bool
+
+	Rules []PrivilegeRule
+}
+
+// PrivilegeRule lets a single user run commands as root.  Commands
+// are absolute paths, optionally followed by arguments, and the user
+// may run anything if there are none.
+type PrivilegeRule struct {
+	User       string
+	NoPassword bool
+	Commands   []string
+}
+
+// Network configures networking with dhcpcd, or hands it all to
+// NetworkManager.  With dhcpcd only the listed interfaces are
+// configured, unless there are none in which case dhcpcd manages
+// every interface it finds.
+type Network struct {
+	// Manager is either dhcpcd or NetworkManager, dhcpcd is
+	// used if unset.  NetworkManager can't be combined with any
+	// of the other settings, it is configured once installed.
+	Manager string
+
+	Interfaces []Interface
+
+	// DNS servers replace those learned over DHCP.
+	DNS []string
+
+	// Wifi networks are joined with wpa_supplicant.
+	Wifi []Wifi
+}
+
+// Interface is a network interface to configure.  It uses DHCP
+// unless Addresses are given, in CIDR notation, at most one each of
+// IPv4 and IPv6.  The gateway is IPv4.
+type Interface struct {
+	Name      string
+	Addresses []string
+	Gateway   string
+}
+
+// Wifi is a wireless network to join.  It is open if there is no
+// PSK, which is either the passphrase or the 64 hex digit key.
+type Wifi struct {
+	SSID string
+	PSK  string
+}
+
+// NetworkManager returns the network manager that is configured,
+// taking the default into account.
+func (c Config) NetworkManager() string {
+	if c.Network.Manager == "" {
+		return "dhcpcd"
+	}
+	return c.Network.Manager
+}
+
+// PrivilegeTool returns the tool that is configured, taking the
+// default into account.
+func (c Config) PrivilegeTool() string {
+	if c.Privilege.Tool == "" {
+		return "sudo"
+	}
+	return c.Privilege.Tool
+}
+
+// HasWheelUser returns true if at least one user is able to become
+// root through the wheel group.
+func (c Config) HasWheelUser() bool {
+	for _, u := range c.Users {
+		for _, g := range u.Groups {
+			if strings.TrimSpace(g) == "wheel" {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -0,0 +1,661 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/console"
+	"github.com/the-maldridge/vInstaller/internal/keyboard"
+	"github.com/the-maldridge/vInstaller/internal/locale"
+	"github.com/the-maldridge/vInstaller/internal/shadow"
+	"github.com/the-maldridge/vInstaller/internal/timezone"
+)
+
+// A ValidationError describes one field of the configuration that is
+// not acceptable.
+type ValidationError struct {
+	Field string
+	Err   error
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+// ValidationErrors is returned by Validate when one or more fields
+// are not acceptable.
+type ValidationErrors []ValidationError
+
+func (v ValidationErrors) Error() string {
+	out := []string{}
+	for _, e := range v {
+		out = append(out, e.Error())
+	}
+	return strings.Join(out, "; ")
+}
+
+var (
+	// ErrUnknownFont is returned for a console font, map, or
+	// unimap that kbd does not have.
+	ErrUnknownFont = errors.New("console font is not known")
+
+	// ErrUnknownLocale is returned for a locale that glibc on the
+	// live system doesn't support.
+	ErrUnknownLocale = errors.New("locale is not supported")
+
+	// ErrBadHardwareClock is returned if the hardware clock is
+	// neither UTC nor localtime.
+	ErrBadHardwareClock = errors.New("hardware clock must be UTC or localtime")
+
+	// ErrBadTTYS is returned for an unreasonable number of ttys.
+	ErrBadTTYS = errors.New("number of ttys must be between 1 and 12")
+
+	// ErrLockRootWithoutWheel is returned if root would be locked
+	// with nobody able to take its place.
+	ErrLockRootWithoutWheel = errors.New("root can only be locked if a user is in the wheel group")
+
+	// ErrBadUsername is returned for a user or group name that
+	// useradd would refuse.
+	ErrBadUsername = errors.New("names must be lower case letters, digits, _ and -, start with a letter or _, and be at most 32 characters")
+
+	// ErrDuplicateUser is returned for a user that is configured
+	// more than once.
+	ErrDuplicateUser = errors.New("user is configured more than once")
+
+	// ErrBadShell is returned for a login shell that isn't an
+	// absolute path.
+	ErrBadShell = errors.New("shell must be an absolute path")
+
+	// ErrBadHome is returned for a home directory that isn't an
+	// absolute path.
+	ErrBadHome = errors.New("home directory must be an absolute path")
+
+	// ErrBadID is returned for a negative UID or GID.
+	ErrBadID = errors.New("UID and GID must not be negative")
+
+	// ErrBadPasswordHash is returned for a password hash that
+	// isn't in a format crypt(3) produces.
+	ErrBadPasswordHash = errors.New("not a crypt(3) password hash")
+
+	// ErrBadSSHKey is returned for something that isn't an SSH
+	// public key.
+	ErrBadSSHKey = errors.New("not an SSH public key")
+
+	// ErrBadPrivilegeTool is returned for anything but sudo or
+	// doas.
+	ErrBadPrivilegeTool = errors.New("privilege tool must be sudo or doas")
+
+	// ErrBadPrivilegeRule is returned for a rule without a valid
+	// user or group, or with a command that isn't an absolute path.
+	ErrBadPrivilegeRule = errors.New("rules need a valid user or group and commands must be absolute paths")
+
+	// ErrBadNetworkManager is returned for anything but dhcpcd or
+	// NetworkManager.
+	ErrBadNetworkManager = errors.New("network manager must be dhcpcd or NetworkManager")
+
+	// ErrNetworkManaged is returned if NetworkManager is combined
+	// with settings it would ignore.
+	ErrNetworkManaged = errors.New("NetworkManager is configured after installation")
+
+	// ErrBadInterface is returned for an interface name the
+	// kernel wouldn't accept.
+	ErrBadInterface = errors.New("not a network interface name")
+
+	// ErrBadAddress is returned for an address that isn't in CIDR
+	// notation, or a gateway or DNS server that isn't an IP.
+	ErrBadAddress = errors.New("not a usable IP address")
+
+	// ErrTooManyAddresses is returned for an interface with more
+	// than one IPv4 or IPv6 address, dhcpcd only sets one of each.
+	ErrTooManyAddresses = errors.New("only one IPv4 and one IPv6 address can be set")
+
+	// ErrBadWifi is returned for a network without an SSID or
+	// with a PSK wpa_supplicant won't take.
+	ErrBadWifi = errors.New("SSID must be set and PSK must be 8 to 63 characters or 64 hex digits")
+
+	// ErrBadHostname is returned for a hostname or domain with a
+	// label that isn't 1 to 63 letters, digits and hyphens, or
+	// that starts or ends with a hyphen.
+	ErrBadHostname = errors.New("not a valid host or domain name")
+
+	// ErrBadService is returned for a service or runsvdir that
+	// isn't a plain name, or a service that is both disabled and
+	// down.
+	ErrBadService = errors.New("service and runsvdir must be names, and a disabled service can't be down")
+
+	// ErrBadLayout is returned for a layout without a disk or
+	// with a scheme that isn't ext4, btrfs or luks.
+	ErrBadLayout = errors.New("a layout needs a disk under /dev and a scheme of ext4, btrfs or luks")
+
+	// ErrNoPassphrase is returned for an encrypted layout without
+	// a passphrase.
+	ErrNoPassphrase = errors.New("an encrypted layout needs a passphrase")
+
+	// ErrLayoutConflict is returned for a filesystem that the
+	// layout would erase or that is mounted where the layout
+	// mounts one.
+	ErrLayoutConflict = errors.New("filesystem conflicts with the disk layout")
+
+	// ErrBadFormat is returned for a filesystem to format that
+	// isn't a device or has a type that can't be created.
+	ErrBadFormat = errors.New("only devices can be formatted, as ext2, ext3, ext4, xfs, btrfs, f2fs, vfat or swap")
+
+	// ErrBadRCVar is returned for a custom rc.conf variable that
+	// isn't a valid shell name or that would clobber one the
+	// installer manages.
+	ErrBadRCVar = errors.New("not a usable rc.conf variable name")
+)
+
+// rcManaged are the rc.conf variables that have their own fields.
+var rcManaged = map[string]bool{
+	"HARDWARECLOCK": true,
+	"TIMEZONE":      true,
+	"KEYMAP":        true,
+	"FONT":          true,
+	"FONT_MAP":      true,
+	"FONT_UNIMAP":   true,
+	"TTYS":          true,
+}
+
+var (
+	rcVarName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	username  = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+	hostLabel = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+	ifaceName = regexp.MustCompile(`^[^\s/:]{1,15}$`)
+	hexKey    = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+
+	// printable excludes the double quote, as wpa_supplicant has
+	// no way of escaping it.
+	printable = regexp.MustCompile(`^[ !#-~]+$`)
+)
+
+// Validate checks the configuration, consulting the live system where
+// a value has to exist on it.  A time zone that is only spelled
+// loosely is corrected to its name in the database, so that nothing
+// later has to.  The error returned, if any, is a ValidationErrors.
+func (c *Config) Validate() error {
+	var errs ValidationErrors
+	check := func(field string, err error) {
+		if err != nil {
+			errs = append(errs, ValidationError{field, err})
+		}
+	}
+
+	check("Hostname", ValidateHostname(c.Hostname))
+	check("Domain", ValidateDomain(c.Domain))
+	if c.Domain != "" && strings.Contains(c.Hostname, ".") {
+		check("Hostname", ErrBadHostname)
+	}
+	for n, h := range c.Hosts {
+		field := fmt.Sprintf("Hosts.%d", n)
+		check(field, ValidateIP(h.IP))
+		if len(h.Names) == 0 {
+			check(field, ErrBadHostname)
+		}
+		for _, name := range h.Names {
+			check(field, ValidateDomain(name))
+		}
+	}
+	zone, err := CanonicalTimeZone(c.TimeZone)
+	check("TimeZone", err)
+	if err == nil {
+		c.TimeZone = zone
+	}
+	check("Locale", ValidateLocale(c.Locale))
+	check("Keyboard", ValidateKeymap(c.Keyboard))
+	check("HardwareClock", ValidateHardwareClock(c.HardwareClock))
+	check("Font", ValidateFont(c.Font))
+	check("FontMap", ValidateFontMap(c.FontMap))
+	check("FontUnimap", ValidateFontUnimap(c.FontUnimap))
+	check("TTYS", ValidateTTYS(c.TTYS))
+	if c.LockRoot && !c.HasWheelUser() {
+		check("LockRoot", ErrLockRootWithoutWheel)
+	}
+	check("RootPasswordHash", ValidatePasswordHash(c.RootPasswordHash))
+	check("RootShell", ValidateShell(c.RootShell))
+	seen := make(map[string]bool)
+	for n, u := range c.Users {
+		field := fmt.Sprintf("Users.%d.", n)
+		check(field+"Username", ValidateUsername(u.Username))
+		if seen[u.Username] {
+			check(field+"Username", ErrDuplicateUser)
+		}
+		seen[u.Username] = true
+		for _, g := range u.Groups {
+			if g = strings.TrimSpace(g); g != "" {
+				check(field+"Groups", ValidateGroup(g))
+			}
+		}
+		check(field+"PasswordHash", ValidatePasswordHash(u.PasswordHash))
+		check(field+"Shell", ValidateShell(u.Shell))
+		check(field+"Home", ValidateHome(u.Home))
+		if u.UID < 0 || u.GID < 0 {
+			check(field+"UID", ErrBadID)
+		}
+		for _, k := range u.SSHKeys {
+			check(field+"SSHKeys", ValidateSSHKey(k))
+		}
+		for _, f := range u.SSHKeyFiles {
+			check(field+"SSHKeyFiles", ValidateSSHKeyFile(f))
+		}
+	}
+	check("Privilege.Tool", ValidatePrivilegeTool(c.Privilege.Tool))
+	for n, r := range c.Privilege.Rules {
+		check(fmt.Sprintf("Privilege.Rules.%d", n), ValidatePrivilegeRule(r))
+	}
+	check("Network.Manager", ValidateNetworkManager(c.Network.Manager))
+	if c.NetworkManager() == "NetworkManager" &&
+		(len(c.Network.Interfaces) > 0 || len(c.Network.DNS) > 0 || len(c.Network.Wifi) > 0) {
+		check("Network", ErrNetworkManaged)
+	}
+	for n, iface := range c.Network.Interfaces {
+		check(fmt.Sprintf("Network.Interfaces.%d", n), ValidateInterface(iface))
+	}
+	for _, d := range c.Network.DNS {
+		check("Network.DNS", ValidateIP(d))
+	}
+	for n, w := range c.Network.Wifi {
+		check(fmt.Sprintf("Network.Wifi.%d", n), ValidateWifi(w))
+	}
+	for n, svc := range c.Services {
+		check(fmt.Sprintf("Services.%d", n), ValidateService(svc))
+	}
+	if c.Layout != (Layout{}) {
+		check("Layout", ValidateLayout(c.Layout))
+	}
+	for n, f := range c.Filesystems {
+		field := fmt.Sprintf("Filesystems.%d", n)
+		if f.Format {
+			check(field, ValidateFormat(f))
+		}
+		if c.Layout.Disk != "" && (f.MountTo == "/" || onDisk(f.FS, c.Layout.Disk)) {
+			check(field, ErrLayoutConflict)
+		}
+	}
+	for name := range c.RCVars {
+		check("RCVars."+name, ValidateRCVar(name))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// ValidateHostname checks a hostname, which may be fully qualified.
+// An empty hostname leaves the default in place.
+func ValidateHostname(name string) error {
+	return ValidateDomain(name)
+}
+
+// ValidateDomain checks each label of a domain name.  A trailing dot
+// is allowed.
+func ValidateDomain(name string) error {
+	name = strings.TrimSuffix(name, ".")
+	if name == "" {
+		return nil
+	}
+	if len(name) > 253 {
+		return ErrBadHostname
+	}
+	for _, l := range strings.Split(name, ".") {
+		if !hostLabel.MatchString(l) {
+			return ErrBadHostname
+		}
+	}
+	return nil
+}
+
+// ValidateTimeZone checks that the live system knows the zone, in
+// any of the spellings timezone.Canonicalize accepts.  An empty zone
+// leaves the system on UTC.
+func ValidateTimeZone(name string) error {
+	_, err := CanonicalTimeZone(name)
+	return err
+}
+
+// CanonicalTimeZone returns the name the live system knows the zone
+// by.  Without a database to look in the zone is returned as it is.
+func CanonicalTimeZone(name string) (string, error) {
+	if name == "" || !timezone.Available("/") {
+		return name, nil
+	}
+	zones, err := timezone.List("/")
+	if err != nil {
+		return "", err
+	}
+	return timezone.Canonicalize(zones, name)
+}
+
+// ValidateLocale checks that glibc on the live system supports the
+// locale.  An empty locale leaves the default in place.
+func ValidateLocale(name string) error {
+	if name == "" || !locale.Available("/") {
+		return nil
+	}
+	locales, err := locale.List("/")
+	if err != nil {
+		return err
+	}
+	for _, l := range locales {
+		if l == name {
+			return nil
+		}
+	}
+	return ErrUnknownLocale
+}
+
+// ValidateKeymap checks that kbd on the live system has the keymap.
+// An empty keymap leaves the default in place.
+func ValidateKeymap(name string) error {
+	return keyboard.Validate("/", name)
+}
+
+// ValidateFont checks that kbd on the live system has the console
+// font.  An empty font leaves the default in place.
+func ValidateFont(name string) error {
+	return checkConsole(name, console.Fonts, ErrUnknownFont)
+}
+
+// ValidateFontMap checks that kbd on the live system has the console
+// map.
+func ValidateFontMap(name string) error {
+	return checkConsole(name, console.FontMaps, ErrUnknownFont)
+}
+
+// ValidateFontUnimap checks that kbd on the live system has the
+// unicode map.
+func ValidateFontUnimap(name string) error {
+	return checkConsole(name, console.FontUnimaps, ErrUnknownFont)
+}
+
+// checkConsole looks name up in a list of kbd data.  Nothing can be
+// checked on a live system without kbd, so anything is allowed.
+func checkConsole(name string, list func(string) ([]string, error), unknown error) error {
+	if name == "" || !console.Available("/") {
+		return nil
+	}
+	names, err := list("/")
+	if err != nil {
+		return err
+	}
+	if !console.Contains(names, name) {
+		return unknown
+	}
+	return nil
+}
+
+// ValidateHardwareClock checks the RTC mode for rc.conf.
+func ValidateHardwareClock(mode string) error {
+	switch mode {
+	case "", "UTC", "localtime":
+		return nil
+	}
+	return ErrBadHardwareClock
+}
+
+// ValidateTTYS checks the number of ttys for rc.conf, zero leaves
+// the default in place.
+func ValidateTTYS(n int) error {
+	if n < 0 || n > 12 {
+		return ErrBadTTYS
+	}
+	return nil
+}
+
+// ValidateRCVar checks the name of a custom rc.conf variable.
+func ValidateRCVar(name string) error {
+	if !rcVarName.MatchString(name) || rcManaged[strings.ToUpper(name)] {
+		return ErrBadRCVar
+	}
+	return nil
+}
+
+// ValidatePrivilegeTool checks the tool used to become root.  An
+// empty tool means sudo.
+func ValidatePrivilegeTool(tool string) error {
+	switch tool {
+	case "", "sudo", "doas":
+		return nil
+	}
+	return ErrBadPrivilegeTool
+}
+
+// ValidatePrivilegeRule checks a rule for a single user.  The user is
+// a username, or a group written as %group for sudo or :group for
+// doas, as it goes into the configuration file as is.  Commas are not
+// allowed in commands as sudo would take them as a separator, nor are
+// newlines which would start another rule.
+func ValidatePrivilegeRule(r PrivilegeRule) error {
+	user := r.User
+	if strings.HasPrefix(user, "%") || strings.HasPrefix(user, ":") {
+		if ValidateGroup(user[1:]) != nil {
+			return ErrBadPrivilegeRule
+		}
+	} else if ValidateUsername(user) != nil {
+		return ErrBadPrivilegeRule
+	}
+	for _, c := range r.Commands {
+		if !strings.HasPrefix(c, "/") || strings.ContainsAny(c, ",\n") {
+			return ErrBadPrivilegeRule
+		}
+	}
+	return nil
+}
+
+// ValidateNetworkManager checks the network manager.  An empty
+// manager means dhcpcd.
+func ValidateNetworkManager(m string) error {
+	switch m {
+	case "", "dhcpcd", "NetworkManager":
+		return nil
+	}
+	return ErrBadNetworkManager
+}
+
+// ValidateInterface checks an interface's name and addresses.  The
+// gateway must be IPv4, IPv6 routers are learned from advertisements.
+// The interface doesn't have to exist on the live system, it may be
+// plugged in later.
+func ValidateInterface(iface Interface) error {
+	if !ifaceName.MatchString(iface.Name) {
+		return ErrBadInterface
+	}
+	v4, v6 := 0, 0
+	for _, a := range iface.Addresses {
+		ip, _, err := net.ParseCIDR(a)
+		if err != nil {
+			return ErrBadAddress
+		}
+		if ip.To4() != nil {
+			v4++
+		} else {
+			v6++
+		}
+	}
+	if v4 > 1 || v6 > 1 {
+		return ErrTooManyAddresses
+	}
+	if iface.Gateway != "" && net.ParseIP(iface.Gateway).To4() == nil {
+		return ErrBadAddress
+	}
+	return nil
+}
+
+// ValidateIP checks a single IP address, such as a DNS server.
+func ValidateIP(ip string) error {
+	if net.ParseIP(ip) == nil {
+		return ErrBadAddress
+	}
+	return nil
+}
+
+// ValidateWifi checks a wireless network.
+func ValidateWifi(w Wifi) error {
+	if w.SSID == "" || len(w.SSID) > 32 {
+		return ErrBadWifi
+	}
+	switch {
+	case w.PSK == "":
+	case len(w.PSK) == 64 && hexKey.MatchString(w.PSK):
+	case len(w.PSK) >= 8 && len(w.PSK) <= 63 && printable.MatchString(w.PSK):
+	default:
+		return ErrBadWifi
+	}
+	return nil
+}
+
+// FilesystemTypes are the filesystems the installer can create.
+var FilesystemTypes = []string{"ext4", "ext3", "ext2", "xfs", "btrfs", "f2fs", "vfat", "swap"}
+
+// ValidateLayout checks a guided disk layout.
+func ValidateLayout(l Layout) error {
+	if !strings.HasPrefix(l.Disk, "/dev/") {
+		return ErrBadLayout
+	}
+	switch l.Scheme {
+	case "ext4", "btrfs":
+	case "luks":
+		if l.Passphrase == "" {
+			return ErrNoPassphrase
+		}
+	default:
+		return ErrBadLayout
+	}
+	return nil
+}
+
+// ValidateFormat checks a filesystem that is to be created.
+func ValidateFormat(f Filesystem) error {
+	if !strings.HasPrefix(f.FS, "/dev/") {
+		return ErrBadFormat
+	}
+	for _, t := range FilesystemTypes {
+		if f.Type == t {
+			return nil
+		}
+	}
+	return ErrBadFormat
+}
+
+// onDisk reports whether dev is disk or one of its partitions, which
+// are numbered after the disk's name, with a p in between if the name
+// ends in a digit.
+func onDisk(dev, disk string) bool {
+	if !strings.HasPrefix(dev, disk) {
+		return false
+	}
+	n := strings.TrimPrefix(strings.TrimPrefix(dev, disk), "p")
+	return n == "" || strings.Trim(n, "0123456789") == ""
+}
+
+// ValidateService checks a service.  Whether it exists can only be
+// checked in the target, once its packages are installed.
+func ValidateService(s Service) error {
+	if !plainName(s.Name) || (s.Runsvdir != "" && !plainName(s.Runsvdir)) {
+		return ErrBadService
+	}
+	if s.Down && s.Disable {
+		return ErrBadService
+	}
+	return nil
+}
+
+func plainName(s string) bool {
+	return s != "" && s != "." && s != ".." && !strings.ContainsAny(s, "/ ")
+}
+
+// ValidateUsername checks a name for useradd.
+func ValidateUsername(name string) error {
+	if !username.MatchString(name) {
+		return ErrBadUsername
+	}
+	return nil
+}
+
+// ValidateGroup checks a group name, which follows the same rules as
+// a username.
+func ValidateGroup(name string) error {
+	return ValidateUsername(name)
+}
+
+// ValidateShell checks a login shell.  An empty shell leaves the
+// default in place.
+func ValidateShell(shell string) error {
+	if shell != "" && !strings.HasPrefix(shell, "/") {
+		return ErrBadShell
+	}
+	return nil
+}
+
+// ValidateHome checks a home directory.  An empty home leaves the
+// default in place.
+func ValidateHome(home string) error {
+	if home != "" && !strings.HasPrefix(home, "/") {
+		return ErrBadHome
+	}
+	return nil
+}
+
+// sshKeyTypes are the key types sshd accepts in authorized_keys.
+var sshKeyTypes = []string{"ssh-rsa", "ssh-dss", "ssh-ed25519", "ecdsa-sha2-", "sk-ssh-ed25519@", "sk-ecdsa-sha2-"}
+
+// ValidatePasswordHash checks that a hash can go into /etc/shadow
+// as is.  An empty hash means the password is used instead.
+func ValidatePasswordHash(hash string) error {
+	if hash == "" || shadow.IsHash(hash) {
+		return nil
+	}
+	return ErrBadPasswordHash
+}
+
+// ValidateSSHKey checks that a line looks like a public key.  Options
+// in front of the key, as authorized_keys allows, are not supported.
+func ValidateSSHKey(key string) error {
+	fields := strings.Fields(key)
+	if len(fields) < 2 {
+		return ErrBadSSHKey
+	}
+	for _, t := range sshKeyTypes {
+		if strings.HasPrefix(fields[0], t) {
+			return nil
+		}
+	}
+	return ErrBadSSHKey
+}
+
+// ValidateSSHKeyFile checks that a file of keys on the live system
+// can be read and contains nothing but keys and comments.
+func ValidateSSHKeyFile(path string) error {
+	keys, err := ReadSSHKeyFile(path)
+	if err != nil {
+		return err
+	}
+	for _, k := range keys {
+		if err := ValidateSSHKey(k); err != nil {
+			return fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+// ReadSSHKeyFile returns the keys in a file, skipping blank lines
+// and comments.
+func ReadSSHKeyFile(path string) ([]string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys := []string{}
+	for _, l := range strings.Split(string(b), "\n") {
+		l = strings.TrimSpace(l)
+		if l != "" && !strings.HasPrefix(l, "#") {
+			keys = append(keys, l)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestValidatePrivilegeRule(t *testing.T) {
+	for _, c := range []struct {
+		rule PrivilegeRule
+		ok   bool
+	}{
+		{PrivilegeRule{User: "alice"}, true},
+		{PrivilegeRule{User: "%wheel"}, true},
+		{PrivilegeRule{User: ":wheel", Commands: []string{"/usr/bin/zzz -f"}}, true},
+		{PrivilegeRule{User: ""}, false},
+		{PrivilegeRule{User: "%"}, false},
+		{PrivilegeRule{User: "alice ALL=(ALL) ALL"}, false},
+		{PrivilegeRule{User: "alice\nbob"}, false},
+		{PrivilegeRule{User: "alice ALL=(ALL) NOPASSWD: ALL\nbob"}, false},
+		{PrivilegeRule{User: "%wheel\npermit nopass bob"}, false},
+		{PrivilegeRule{User: "alice", Commands: []string{"zzz"}}, false},
+		{PrivilegeRule{User: "alice", Commands: []string{"/bin/a, /bin/b"}}, false},
+		{PrivilegeRule{User: "alice", Commands: []string{"/bin/a\nbob ALL=(ALL) ALL"}}, false},
+	} {
+		if err := ValidatePrivilegeRule(c.rule); (err == nil) != c.ok {
+			t.Errorf("ValidatePrivilegeRule(%q) = %v", c.rule.User, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package console knows about the console fonts, font maps and
+// keymaps that kbd ships, so that the values put into rc.conf can be
+// checked before they are written.
+package console
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// KbdDir is where kbd keeps its data, relative to the root of a
+// system.
+const KbdDir = "usr/share/kbd"
+
+// These suffixes are stripped from file names to get the names that
+// setfont and loadkeys accept.
+var suffixes = []string{".gz", ".psfu", ".psf", ".cp", ".fnt", ".trans", ".acm", ".uni", ".map"}
+
+// Available returns true if the system at root has kbd data to
+// check against.
+func Available(root string) bool {
+	stat, err := os.Stat(filepath.Join(root, KbdDir))
+	return err == nil && stat.IsDir()
+}
+
+// Fonts returns the console fonts available on the system at root.
+func Fonts(root string) ([]string, error) {
+	return names(filepath.Join(root, KbdDir, "consolefonts"), false)
+}
+
+// FontMaps returns the console maps available on the system at
+// root.
+func FontMaps(root string) ([]string, error) {
+	return names(filepath.Join(root, KbdDir, "consoletrans"), false)
+}
+
+// FontUnimaps returns the unicode maps available on the system at
+// root.
+func FontUnimaps(root string) ([]string, error) {
+	return names(filepath.Join(root, KbdDir, "unimaps"), false)
+}
+
+// Keymaps returns the keymaps available on the system at root.
+// Keymaps are spread over directories by architecture and layout,
+// but loadkeys only wants the name of the map.
+func Keymaps(root string) ([]string, error) {
+	return names(filepath.Join(root, KbdDir, "keymaps"), true)
+}
+
+// Contains returns true if name is in a list returned by one of the
+// other functions in this package.
+func Contains(list []string, name string) bool {
+	i := sort.SearchStrings(list, name)
+	return i < len(list) && list[i] == name
+}
+
+// names lists the files in dir with their suffixes removed, sorted
+// and without duplicates.
+func names(dir string, recurse bool) ([]string, error) {
+	seen := make(map[string]bool)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && !recurse {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// The include directory holds fragments that can't
+		// be loaded on their own.
+		if strings.Contains(path, "/include/") {
+			return nil
+		}
+		seen[trimSuffixes(info.Name())] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]string, 0, len(seen))
+	for n := range seen {
+		out = append(out, n)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func trimSuffixes(name string) string {
+	for {
+		trimmed := name
+		for _, s := range suffixes {
+			trimmed = strings.TrimSuffix(trimmed, s)
+		}
+		if trimmed == name {
+			return name
+		}
+		name = trimmed
+	}
+}
@@ -0,0 +1,133 @@
+package frontend
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// accessibilityArg is what the boot menu entry of the live images
+// that starts a screen reader adds to the kernel command line.
+const accessibilityArg = "live.accessibility"
+
+var (
+	accessibleFlag = flag.Bool("accessible", false, "Screen reader friendly output, which live.accessibility on the kernel command line also asks for")
+
+	accessibleOnce sync.Once
+	accessible     bool
+)
+
+// Accessible reports whether the frontends should suit somebody
+// using a screen reader: output is written line after line and never
+// redrawn, lists say how long they are and choices are read back.
+func Accessible() bool {
+	accessibleOnce.Do(func() {
+		accessible = *accessibleFlag
+		for _, arg := range kernelArgs() {
+			if arg == accessibilityArg {
+				accessible = true
+			}
+		}
+	})
+	return accessible
+}
+
+// An Announcer passes a frontend's output on to the terminal and, in
+// accessibility mode, reads it aloud too.  Everything written since
+// the last call to Announce is spoken in one go, which a frontend
+// does when it is about to wait for an answer.
+type Announcer struct {
+	w io.Writer
+
+	once sync.Once
+	say  func(string) error
+	buf  bytes.Buffer
+}
+
+// NewAnnouncer returns an Announcer that writes to w.  How to speak is
+// only worked out once there is something to say, as flags have to
+// be parsed first.
+func NewAnnouncer(w io.Writer) *Announcer {
+	return &Announcer{w: w}
+}
+
+func (a *Announcer) Write(p []byte) (int, error) {
+	if a.speaking() {
+		a.buf.Write(p)
+	}
+	return a.w.Write(p)
+}
+
+// Announce reads aloud what was written since it was last called.
+// Speech is turned off for good if it fails, rather than failing
+// every time.
+func (a *Announcer) Announce() {
+	if !a.speaking() {
+		return
+	}
+	text := strings.TrimSpace(a.buf.String())
+	a.buf.Reset()
+	if text == "" {
+		return
+	}
+	if err := a.say(text); err != nil {
+		log.Printf("Speech failed and is turned off: %v", err)
+		a.say = nil
+	}
+}
+
+// Discard forgets what was written since Announce was last called,
+// for output that is better read on demand than spoken.
+func (a *Announcer) Discard() {
+	a.buf.Reset()
+}
+
+func (a *Announcer) speaking() bool {
+	a.once.Do(func() {
+		if Accessible() {
+			a.say = speech()
+		}
+	})
+	return a.say != nil
+}
+
+// speech returns a way to read text aloud on the live system, or nil
+// if there isn't one or none is needed.  speakup, voiced by espeakup
+// on the live images, reads the virtual consoles by itself, so only
+// output elsewhere, such as a serial line, has to be sent to its
+// synthesizer.  Otherwise speech-dispatcher is used if it is there.
+func speech() func(string) error {
+	if _, err := os.Stat("/sys/accessibility/speakup"); err == nil {
+		if onVirtualConsole() {
+			log.Println("speakup is reading the console")
+			return nil
+		}
+		if _, err := os.Stat("/dev/synth"); err == nil {
+			log.Println("Reading prompts aloud with speakup")
+			return func(text string) error {
+				f, err := os.OpenFile("/dev/synth", os.O_WRONLY, 0)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				_, err = io.WriteString(f, text+"\n")
+				return err
+			}
+		}
+	}
+	if path, err := exec.LookPath("spd-say"); err == nil {
+		log.Println("Reading prompts aloud with speech-dispatcher")
+		return func(text string) error {
+			return exec.Command(path, "-l", i18n.Language(), "--", text).Run()
+		}
+	}
+	log.Println("Neither speakup nor speech-dispatcher is available, prompts won't be read aloud")
+	return nil
+}
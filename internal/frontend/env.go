@@ -0,0 +1,71 @@
+package frontend
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// serialDevices are the prefixes of the terminals that are serial
+// lines or hypervisor consoles rather than a screen and keyboard.
+var serialDevices = []string{"/dev/ttyS", "/dev/ttyAMA", "/dev/ttyUSB", "/dev/ttymxc", "/dev/hvc", "/dev/xvc"}
+
+// onTerminal reports whether stdin is a terminal.  Being a character
+// device isn't enough, as /dev/null is one too.
+func onTerminal() bool {
+	var t syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, os.Stdin.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t)))
+	return errno == 0
+}
+
+// onSerialConsole reports whether stdin is a serial console, going
+// by the device it is, or by the console the kernel was given when
+// that is all there is to go on.
+func onSerialConsole() bool {
+	if dev, err := os.Readlink("/proc/self/fd/0"); err == nil && dev != "/dev/console" {
+		return isSerialDevice(dev)
+	}
+
+	// The last console given is the one /dev/console is.
+	console := ""
+	for _, arg := range kernelArgs() {
+		if strings.HasPrefix(arg, "console=") {
+			console = strings.SplitN(strings.TrimPrefix(arg, "console="), ",", 2)[0]
+		}
+	}
+	return console != "" && isSerialDevice("/dev/"+console)
+}
+
+// onVirtualConsole reports whether stdin is one of the virtual
+// consoles on the screen, such as /dev/tty1.
+func onVirtualConsole() bool {
+	dev, err := os.Readlink("/proc/self/fd/0")
+	if err != nil {
+		return false
+	}
+	if dev == "/dev/console" {
+		return !onSerialConsole()
+	}
+	n := strings.TrimPrefix(dev, "/dev/tty")
+	return n != dev && n != "" && strings.Trim(n, "0123456789") == ""
+}
+
+// kernelArgs returns the kernel command line, split into arguments.
+func kernelArgs() []string {
+	cmdline, err := ioutil.ReadFile("/proc/cmdline")
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(cmdline))
+}
+
+func isSerialDevice(dev string) bool {
+	for _, prefix := range serialDevices {
+		if strings.HasPrefix(dev, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -18,4 +18,8 @@ var (
 	// ConfirmInstallation() function if the user chooses not to
 	// install the system.
 	ErrInstallationAborted = errors.New("the installation was cancelled")
+
+	// ErrNoFrontend is returned by New if no frontend was asked
+	// for and none suits the environment.
+	ErrNoFrontend = errors.New("no frontend suits this environment")
 )
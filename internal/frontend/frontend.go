@@ -2,9 +2,15 @@ package frontend
 
 import (
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"sort"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
 )
 
 // The InstallerFrontend will fetch an installer config, and then
@@ -12,50 +18,193 @@ import (
 type InstallerFrontend interface {
 	GetInstallerConfig() (*config.Config, error)
 	ConfirmInstallation() error
-	ShowInstallationProgress(<-chan string, <-chan error, <-chan bool)
+	ShowInstallationProgress(<-chan i18n.Message, <-chan error, <-chan bool)
+}
+
+// A Canceller is a frontend that lets the user cancel the
+// installation while it shows its progress.  cancel is called to do
+// so, which cancels the context the installer runs with.
+type Canceller interface {
+	SetCancel(cancel func())
 }
 
 // Factory creates a new InstallerFrontend and returns it
 type Factory func() (InstallerFrontend, error)
 
+// Capability describes what a frontend can do and what it needs.
+// Capabilities are combined with |.
+type Capability uint
+
+// The capabilities a frontend may have.
+const (
+	// Interactive frontends ask a person for the configuration.
+	Interactive Capability = 1 << iota
+
+	// NeedsTTY frontends only work on a terminal.
+	NeedsTTY
+
+	// FullScreen frontends draw on the whole terminal, which
+	// serial consoles often don't handle well.
+	FullScreen
+
+	// Remote frontends can be used from another machine.
+	Remote
+
+	// Unattended frontends need nobody to be present.
+	Unattended
+
+	// ScreenReader frontends can be followed with a screen reader.
+	ScreenReader
+)
+
+var capabilityNames = []string{"interactive", "needs tty", "full screen", "remote", "unattended", "screen reader"}
+
+// Has reports whether all of the capabilities in o are in c.
+func (c Capability) Has(o Capability) bool {
+	return c&o == o
+}
+
+func (c Capability) String() string {
+	names := []string{}
+	for n, name := range capabilityNames {
+		if c.Has(1 << uint(n)) {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Registration describes a frontend to the registry.
+type Registration struct {
+	Name         string
+	Description  string
+	Capabilities Capability
+	Factory      Factory
+
+	// Detect reports whether the environment asks for the
+	// frontend, for example because one of its flags was given.
+	// It may be nil.
+	Detect func() bool
+}
+
 var (
-	frontends map[string]Factory
+	frontends map[string]Registration
 
-	frontend = flag.String("frontend", "", "Frontend interface to use")
+	frontend = flag.String("frontend", "", "Frontend interface to use, or list to show the available ones")
 )
 
 func init() {
-	frontends = make(map[string]Factory)
+	frontends = make(map[string]Registration)
 }
 
 // Register adds a new frontend
-func Register(name string, factory Factory) {
-	if _, ok := frontends[name]; ok {
+func Register(r Registration) {
+	if _, ok := frontends[r.Name]; ok {
 		return
 	}
-	frontends[name] = factory
+	frontends[r.Name] = r
 }
 
-// New returns a frontend ready to use
+// New returns a frontend ready to use.  If none was asked for with
+// -frontend then one is chosen to suit the environment.
 func New() (InstallerFrontend, error) {
-	if *frontend == "" && len(frontends) == 1 {
-		log.Println("No frontend specified")
-		log.Println("Initializing ", List()[0])
-		return frontends[List()[0]]()
+	if *frontend == "" {
+		r, err := choose()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("No frontend specified, using %s", r.Name)
+		return r.Factory()
 	}
-	if f, ok := frontends[*frontend]; ok {
+	if r, ok := frontends[*frontend]; ok {
 		log.Println("Using explicitely specified frontend")
-		return f()
+		if Accessible() && !r.Capabilities.Has(ScreenReader) {
+			log.Printf("The %s frontend doesn't work well with a screen reader", r.Name)
+		}
+		return r.Factory()
 	}
-	log.Println("Problem finding frontend")
+	log.Printf("There is no frontend %q, the frontends are: %s", *frontend, strings.Join(List(), ", "))
 	return nil, ErrUnknownFrontend
 }
 
-// List returns a list of all frontends
+// choose picks the frontend for the environment.  A frontend whose
+// flags were given comes first.  Otherwise a terminal gets an
+// interactive frontend, full screen unless it is a serial console or
+// somebody is using a screen reader.
+func choose() (Registration, error) {
+	regs := Registrations()
+	if len(regs) == 1 {
+		return regs[0], nil
+	}
+	for _, r := range regs {
+		if r.Detect != nil && r.Detect() {
+			return r, nil
+		}
+	}
+
+	if !onTerminal() {
+		log.Println("Not running on a terminal, choose a frontend with -frontend or see -frontend list")
+		return Registration{}, ErrNoFrontend
+	}
+	if Accessible() {
+		for _, r := range regs {
+			if r.Capabilities.Has(Interactive | NeedsTTY | ScreenReader) {
+				return r, nil
+			}
+		}
+		log.Println("No frontend on a terminal works with a screen reader")
+	}
+	serial := onSerialConsole()
+	var found *Registration
+	for n, r := range regs {
+		if !r.Capabilities.Has(Interactive | NeedsTTY) {
+			continue
+		}
+		if r.Capabilities.Has(FullScreen) != serial {
+			return r, nil
+		}
+		if found == nil {
+			found = &regs[n]
+		}
+	}
+	if found == nil {
+		log.Println("No frontend works on a terminal, choose one with -frontend or see -frontend list")
+		return Registration{}, ErrNoFrontend
+	}
+	return *found, nil
+}
+
+// Listing reports whether -frontend list was given, in which case
+// PrintList should be called instead of New.
+func Listing() bool {
+	return *frontend == "list"
+}
+
+// PrintList writes a table of the frontends to w.
+func PrintList(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tDESCRIPTION\tCAPABILITIES")
+	for _, r := range Registrations() {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Name, r.Description, r.Capabilities)
+	}
+	tw.Flush()
+}
+
+// Registrations returns all frontends sorted by name.
+func Registrations() []Registration {
+	l := []Registration{}
+	for _, r := range frontends {
+		l = append(l, r)
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i].Name < l[j].Name })
+	return l
+}
+
+// List returns a sorted list of all frontends
 func List() []string {
 	l := []string{}
-	for f := range frontends {
-		l = append(l, f)
+	for _, r := range Registrations() {
+		l = append(l, r.Name)
 	}
 	return l
 }
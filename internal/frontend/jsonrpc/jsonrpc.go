@@ -0,0 +1,349 @@
+// Package jsonrpc provides a frontend for provisioning tools.  It
+// speaks JSON-RPC 2.0, one message per line, either on stdin and
+// stdout or on a Unix socket given with -socket.
+//
+// The client calls these methods in order:
+//
+//	system   returns the hardware of the machine and the disks it
+//	         can be installed to, at any time
+//	config   takes a config.Config and returns {"Valid": ...} with
+//	         the problems found, until a valid one is accepted
+//	confirm  takes {"Proceed": true} to start the installation
+//
+// While installing, the frontend sends notifications: "output" for
+// every line the installer prints, with its message ID so that the
+// client can translate it and the step it is on if the line starts
+// one, "error" for every error, and "finished" at the end.
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/installer"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+var socket = flag.String("socket", "", "Unix socket the jsonrpc frontend listens on instead of stdin and stdout")
+
+// Frontend is driven by another program over JSON-RPC.
+type Frontend struct {
+	sysinfo *sysinfo.System
+
+	in       io.Reader
+	out      io.Writer
+	socket   string
+	listener net.Listener
+
+	requests chan request
+	gone     chan struct{}
+	started  bool
+
+	mu     sync.Mutex
+	client *client
+}
+
+// New returns a frontend on stdin and stdout, or on the socket given
+// with -socket.
+func New() (frontend.InstallerFrontend, error) {
+	if *socket != "" {
+		return NewSocket(*socket, nil), nil
+	}
+	return NewStream(os.Stdin, os.Stdout, nil), nil
+}
+
+// NewStream returns a frontend that reads requests from in and
+// writes to out.  The machine is described by sys, which is
+// discovered if nil.
+func NewStream(in io.Reader, out io.Writer, sys *sysinfo.System) *Frontend {
+	return &Frontend{
+		sysinfo:  sys,
+		in:       in,
+		out:      out,
+		requests: make(chan request),
+		gone:     make(chan struct{}),
+	}
+}
+
+// NewSocket returns a frontend that listens on a Unix socket at path.
+// One client is served at a time, and a client that goes away can be
+// replaced by another.
+func NewSocket(path string, sys *sysinfo.System) *Frontend {
+	return &Frontend{
+		sysinfo:  sys,
+		socket:   path,
+		requests: make(chan request),
+		gone:     make(chan struct{}),
+	}
+}
+
+func init() {
+	frontend.Register(frontend.Registration{
+		Name:         "jsonrpc",
+		Description:  "Take orders from a provisioning tool on stdin and stdout or -socket",
+		Capabilities: frontend.Remote | frontend.Unattended,
+		Factory:      New,
+		Detect:       func() bool { return *socket != "" },
+	})
+}
+
+// start begins reading requests.
+func (f *Frontend) start() error {
+	if f.started {
+		return nil
+	}
+	f.started = true
+	if f.sysinfo == nil {
+		f.sysinfo = sysinfo.DiscoverHardware()
+	}
+
+	if f.socket == "" {
+		go func() {
+			f.serve(f.in, f.out)
+			close(f.gone)
+		}()
+		return nil
+	}
+
+	// The socket is made in a directory no one else can enter and
+	// only moved into place once it is private, so that no one can
+	// connect in between.
+	dir, err := ioutil.TempDir(filepath.Dir(f.socket), ".vInstaller")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	tmp := filepath.Join(dir, "socket")
+	l, err := net.Listen("unix", tmp)
+	if err != nil {
+		return err
+	}
+	os.Remove(f.socket)
+	if err := os.Chmod(tmp, 0600); err != nil {
+		l.Close()
+		return err
+	}
+	if err := os.Rename(tmp, f.socket); err != nil {
+		l.Close()
+		return err
+	}
+	f.listener = l
+	log.Printf("Waiting for a client on %s", f.socket)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			f.serve(conn, conn)
+			conn.Close()
+		}
+	}()
+	return nil
+}
+
+// serve reads requests from one client until it goes away.
+func (f *Frontend) serve(in io.Reader, out io.Writer) {
+	c := &client{enc: json.NewEncoder(out)}
+	f.mu.Lock()
+	f.client = c
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.client = nil
+		f.mu.Unlock()
+	}()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 4096), maxMessage)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		r := request{client: c}
+		if err := json.Unmarshal(line, &r); err != nil {
+			c.send(response{JSONRPC: "2.0", Error: &rpcError{codeParseError, err.Error()}})
+			continue
+		}
+		if r.JSONRPC != "2.0" || r.Method == "" {
+			c.send(response{JSONRPC: "2.0", ID: r.ID, Error: &rpcError{codeInvalidRequest, "not a JSON-RPC 2.0 request"}})
+			continue
+		}
+		f.requests <- r
+	}
+	if err := scanner.Err(); err != nil {
+		log.Printf("The jsonrpc client went away: %v", err)
+	}
+}
+
+// next returns the next request.  It fails if the only client there
+// can be has gone away.
+func (f *Frontend) next() (request, error) {
+	select {
+	case r := <-f.requests:
+		return r, nil
+	case <-f.gone:
+		return request{}, io.EOF
+	}
+}
+
+// notify sends a notification to the client, if one is connected.
+func (f *Frontend) notify(method string, params interface{}) {
+	f.mu.Lock()
+	c := f.client
+	f.mu.Unlock()
+	if c != nil {
+		c.send(notification{JSONRPC: "2.0", Method: method, Params: params})
+	}
+}
+
+// answer handles the requests that don't move the installation on,
+// and rejects the ones that can't be made in the current phase.
+func (f *Frontend) answer(r request, phase string) {
+	switch r.Method {
+	case "system":
+		r.reply(f.sysinfo.Report())
+	case "config", "confirm":
+		r.fail(codeWrongPhase, fmt.Sprintf("%s can't be called while %s", r.Method, phase))
+	default:
+		r.fail(codeMethodNotFound, fmt.Sprintf("there is no method %q", r.Method))
+	}
+}
+
+// GetInstallerConfig waits for the client to send a valid
+// configuration.  Invalid ones are answered with what is wrong with
+// them, and the client may try again.
+func (f *Frontend) GetInstallerConfig() (*config.Config, error) {
+	if err := f.start(); err != nil {
+		return nil, err
+	}
+	for {
+		r, err := f.next()
+		if err != nil {
+			return nil, frontend.ErrConfigUnobtainable
+		}
+		if r.Method != "config" {
+			f.answer(r, "configuring")
+			continue
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(r.Params))
+		dec.DisallowUnknownFields()
+		c := new(config.Config)
+		if err := dec.Decode(c); err != nil {
+			r.fail(codeInvalidParams, err.Error())
+			continue
+		}
+		if err := c.Validate(); err != nil {
+			res := configResult{}
+			if errs, ok := err.(config.ValidationErrors); ok {
+				for _, e := range errs {
+					res.Errors = append(res.Errors, fieldError{e.Field, e.Err.Error()})
+				}
+			} else {
+				res.Errors = []fieldError{{"", err.Error()}}
+			}
+			r.reply(res)
+			continue
+		}
+		r.reply(configResult{Valid: true, Summary: c.String()})
+		return c, nil
+	}
+}
+
+// ConfirmInstallation waits for the client to start or call off the
+// installation.
+func (f *Frontend) ConfirmInstallation() error {
+	for {
+		r, err := f.next()
+		if err != nil {
+			f.stop()
+			return frontend.ErrInstallationAborted
+		}
+		if r.Method != "confirm" {
+			f.answer(r, "waiting for confirmation")
+			continue
+		}
+
+		var p confirmParams
+		if err := json.Unmarshal(r.Params, &p); err != nil {
+			r.fail(codeInvalidParams, err.Error())
+			continue
+		}
+		r.reply(p)
+		if !p.Proceed {
+			f.stop()
+			return frontend.ErrInstallationAborted
+		}
+		return nil
+	}
+}
+
+// ShowInstallationProgress sends the client a notification for every
+// line of output and every error.
+func (f *Frontend) ShowInstallationProgress(output <-chan i18n.Message, errors <-chan error, done <-chan bool) {
+	installing := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case r := <-f.requests:
+				f.answer(r, "installing")
+			case <-installing:
+				return
+			}
+		}
+	}()
+
+	failed := false
+	for output != nil || errors != nil {
+		select {
+		case o, ok := <-output:
+			if !ok {
+				output = nil
+				continue
+			}
+			p := outputParams{Line: o.String(), ID: o.ID, Args: o.Args}
+			if n, total, name, ok := installer.ParseProgress(o); ok {
+				p.Step, p.Total, p.Name = n, total, name
+			}
+			f.notify("output", p)
+		case e, ok := <-errors:
+			if !ok {
+				errors = nil
+				continue
+			}
+			failed = true
+			f.notify("error", errorParams{e.Error()})
+		case <-done:
+			// The installer keeps reporting until it closes
+			// its channels.
+			done = nil
+		}
+	}
+	f.notify("finished", finishedParams{failed})
+
+	close(installing)
+	f.stop()
+}
+
+// stop stops listening on the socket.
+func (f *Frontend) stop() {
+	if f.listener != nil {
+		f.listener.Close()
+		os.Remove(f.socket)
+	}
+}
@@ -0,0 +1,233 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// A message is anything the frontend sends, a response or a
+// notification.
+type message struct {
+	ID     json.RawMessage
+	Method string
+	Params json.RawMessage
+	Result json.RawMessage
+	Error  *rpcError
+}
+
+// peer is the provisioning tool at the other end.
+type peer struct {
+	t   *testing.T
+	enc *json.Encoder
+	dec *json.Decoder
+	id  int
+}
+
+func newPeer(t *testing.T, w io.Writer, r io.Reader) *peer {
+	return &peer{t: t, enc: json.NewEncoder(w), dec: json.NewDecoder(r)}
+}
+
+// read returns the next message from the frontend.
+func (p *peer) read() message {
+	p.t.Helper()
+	var m message
+	if err := p.dec.Decode(&m); err != nil {
+		p.t.Fatal(err)
+	}
+	return m
+}
+
+// call sends a request and decodes the result into out, if there is
+// one, returning the error the frontend answered with.
+func (p *peer) call(method string, params, out interface{}) *rpcError {
+	p.t.Helper()
+	p.id++
+	req := map[string]interface{}{"jsonrpc": "2.0", "id": p.id, "method": method}
+	if params != nil {
+		req["params"] = params
+	}
+	if err := p.enc.Encode(req); err != nil {
+		p.t.Fatal(err)
+	}
+	m := p.read()
+	if string(m.ID) != string(mustJSON(p.id)) {
+		p.t.Fatalf("%s: got a reply to %s", method, m.ID)
+	}
+	if m.Error == nil && out != nil {
+		if err := json.Unmarshal(m.Result, out); err != nil {
+			p.t.Fatalf("%s: %v", method, err)
+		}
+	}
+	return m.Error
+}
+
+func mustJSON(v interface{}) []byte {
+	b, _ := json.Marshal(v)
+	return b
+}
+
+func TestProtocol(t *testing.T) {
+	reqR, reqW := io.Pipe()
+	respR, respW := io.Pipe()
+	defer reqW.Close()
+	f := NewStream(reqR, respW, &sysinfo.System{})
+	p := newPeer(t, reqW, respR)
+
+	configs := make(chan *config.Config, 1)
+	go func() {
+		cfg, _ := f.GetInstallerConfig()
+		configs <- cfg
+	}()
+
+	var sys sysinfo.Report
+	if err := p.call("system", nil, &sys); err != nil {
+		t.Fatalf("system: %v", err)
+	}
+	if sys.Disks == nil || len(sys.Disks) != 0 {
+		t.Errorf("system without disks: Disks %v, want []", sys.Disks)
+	}
+
+	if err := p.call("confirm", confirmParams{true}, nil); err == nil || err.Code != codeWrongPhase {
+		t.Errorf("confirm while configuring: %v, want code %d", err, codeWrongPhase)
+	}
+	if err := p.call("frobnicate", nil, nil); err == nil || err.Code != codeMethodNotFound {
+		t.Errorf("unknown method: %v, want code %d", err, codeMethodNotFound)
+	}
+	if err := p.call("config", map[string]string{"NoSuchField": "x"}, nil); err == nil || err.Code != codeInvalidParams {
+		t.Errorf("config with an unknown field: %v, want code %d", err, codeInvalidParams)
+	}
+
+	var res configResult
+	if err := p.call("config", config.Config{Hostname: "not a hostname"}, &res); err != nil {
+		t.Fatalf("invalid config: %v", err)
+	}
+	if res.Valid || len(res.Errors) != 1 || res.Errors[0].Field != "Hostname" {
+		t.Errorf("invalid config: %+v, want an error for Hostname", res)
+	}
+
+	cfg := config.Config{Hostname: "void", RootPassword: "root-secret"}
+	res = configResult{}
+	if err := p.call("config", cfg, &res); err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	if !res.Valid || res.Summary == "" {
+		t.Errorf("valid config: %+v", res)
+	}
+	if got := <-configs; got == nil || got.Hostname != "void" {
+		t.Fatalf("GetInstallerConfig returned %v", got)
+	}
+
+	confirmed := make(chan error, 1)
+	go func() { confirmed <- f.ConfirmInstallation() }()
+	if err := p.call("config", cfg, nil); err == nil || err.Code != codeWrongPhase {
+		t.Errorf("config while waiting for confirmation: %v, want code %d", err, codeWrongPhase)
+	}
+	var proceed confirmParams
+	if err := p.call("confirm", confirmParams{true}, &proceed); err != nil || !proceed.Proceed {
+		t.Fatalf("confirm: %v, %+v", err, proceed)
+	}
+	if err := <-confirmed; err != nil {
+		t.Fatalf("ConfirmInstallation: %v", err)
+	}
+
+	output := make(chan i18n.Message)
+	errs := make(chan error)
+	done := make(chan bool)
+	go func() {
+		output <- i18n.M("installer.step", 1, 1, "base-system")
+		output <- i18n.Text("installing")
+		errs <- errors.New("something broke")
+		close(done)
+		close(output)
+		close(errs)
+	}()
+	go f.ShowInstallationProgress(output, errs, done)
+
+	for n, want := range []struct {
+		method string
+		params string
+	}{
+		{"output", string(mustJSON(outputParams{
+			Line: i18n.M("installer.step", 1, 1, "base-system").String(),
+			ID:   "installer.step", Args: []string{"1", "1", "base-system"},
+			Step: 1, Total: 1, Name: "base-system",
+		}))},
+		{"output", `{"Line":"installing","Args":["installing"]}`},
+		{"error", `{"Message":"something broke"}`},
+		{"finished", `{"Failed":true}`},
+	} {
+		m := p.read()
+		if m.Method != want.method || string(m.Params) != want.params {
+			t.Errorf("notification %d is %s %s, want %s %s", n, m.Method, m.Params, want.method, want.params)
+		}
+	}
+}
+
+func TestSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "vinstaller")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "vInstaller.sock")
+	f := NewSocket(path, &sysinfo.System{})
+
+	configs := make(chan *config.Config, 1)
+	go func() {
+		cfg, _ := f.GetInstallerConfig()
+		configs <- cfg
+	}()
+
+	// The socket only shows up once it is ready.
+	var info os.FileInfo
+	for n := 0; ; n++ {
+		if info, err = os.Lstat(path); err == nil {
+			break
+		}
+		if n == 100 {
+			t.Fatal("the socket wasn't created")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if info.Mode()&os.ModeSocket == 0 || info.Mode().Perm() != 0600 {
+		t.Errorf("the socket has mode %v, want a socket with 0600", info.Mode())
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	p := newPeer(t, conn, conn)
+	var res configResult
+	if err := p.call("config", config.Config{Hostname: "void"}, &res); err != nil || !res.Valid {
+		t.Fatalf("config: %v, %+v", err, res)
+	}
+	if got := <-configs; got == nil || got.Hostname != "void" {
+		t.Fatalf("GetInstallerConfig returned %v", got)
+	}
+
+	confirmed := make(chan error, 1)
+	go func() { confirmed <- f.ConfirmInstallation() }()
+	if err := p.call("confirm", confirmParams{false}, nil); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if err := <-confirmed; err != frontend.ErrInstallationAborted {
+		t.Errorf("ConfirmInstallation: %v, want %v", err, frontend.ErrInstallationAborted)
+	}
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("the socket is still there after calling off the installation")
+	}
+}
@@ -0,0 +1,112 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+// Error codes from the JSON-RPC 2.0 specification, and one of our own
+// for requests that are valid but come at the wrong time.
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+	codeWrongPhase     = -32000
+)
+
+// maxMessage is the longest line a client may send.
+const maxMessage = 1 << 20
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+
+	client *client
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// A client is the other end of a connection.  Writes are serialized
+// as replies and notifications are sent from different goroutines.
+type client struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func (c *client) send(v interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.enc.Encode(v)
+}
+
+// reply answers a request.  Requests without an id are notifications
+// and don't get an answer.
+func (r request) reply(result interface{}) {
+	if r.ID == nil {
+		return
+	}
+	r.client.send(response{JSONRPC: "2.0", ID: r.ID, Result: result})
+}
+
+func (r request) fail(code int, msg string) {
+	if r.ID == nil {
+		return
+	}
+	r.client.send(response{JSONRPC: "2.0", ID: r.ID, Error: &rpcError{code, msg}})
+}
+
+// The results and notification parameters of the protocol.
+
+type fieldError struct {
+	Field string
+	Error string
+}
+
+type configResult struct {
+	Valid   bool
+	Errors  []fieldError `json:",omitempty"`
+	Summary string       `json:",omitempty"`
+}
+
+type confirmParams struct {
+	Proceed bool
+}
+
+// outputParams carry a line both as the installer's language shows
+// it and as the message ID and arguments, for clients that translate
+// it themselves.  Lines of command output have no ID.
+type outputParams struct {
+	Line  string
+	ID    string   `json:",omitempty"`
+	Args  []string `json:",omitempty"`
+	Step  int      `json:",omitempty"`
+	Total int      `json:",omitempty"`
+	Name  string   `json:",omitempty"`
+}
+
+type errorParams struct {
+	Message string
+}
+
+type finishedParams struct {
+	Failed bool
+}
@@ -0,0 +1,58 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// out is where everything the frontend says goes.  In accessibility
+// mode it is read aloud whenever the frontend waits for an answer.
+var out = frontend.NewAnnouncer(os.Stdout)
+
+// heading introduces a list.  A screen reader can't glance at how
+// long a list is, so in accessibility mode the heading says.
+func heading(title string, n int) {
+	switch {
+	case !frontend.Accessible():
+		if title != "" {
+			fmt.Fprintln(out, title)
+		}
+	case title == "":
+		fmt.Fprintln(out, i18n.T("prompt.count", n))
+	default:
+		fmt.Fprintln(out, i18n.T("prompt.count-titled", strings.TrimSuffix(title, ":"), n))
+	}
+}
+
+// echo reads back what was chosen in accessibility mode, as it may
+// not be what was typed: a blank answer keeps the current value and a
+// number stands for an item in a list.
+func echo(choice string) {
+	if !frontend.Accessible() {
+		return
+	}
+	if choice == "" {
+		fmt.Fprintln(out, i18n.T("prompt.echo-empty"))
+	} else {
+		fmt.Fprintln(out, i18n.T("prompt.echo", choice))
+	}
+	out.Announce()
+}
+
+// checkbox shows whether an item in a checklist is checked, in words
+// in accessibility mode.
+func checkbox(checked bool) string {
+	switch {
+	case !frontend.Accessible() && checked:
+		return "[x]"
+	case !frontend.Accessible():
+		return "[ ]"
+	case checked:
+		return i18n.T("prompt.checked")
+	}
+	return i18n.T("prompt.unchecked")
+}
@@ -0,0 +1,232 @@
+package prompt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/layout"
+	"github.com/the-maldridge/vInstaller/internal/shadow"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// promptDisks chooses the disk to install to and how it is laid out,
+// and doesn't let it go until the user has seen what will be erased.
+func (f *Frontend) promptDisks() {
+	for {
+		disks := f.sysinfo.InstallableDisks()
+		heading(i18n.T("prompt.disks"), len(disks))
+		for n, d := range disks {
+			fmt.Fprintf(out, "  %d) %s\n", n+1, d)
+			for _, p := range d.Partitions {
+				fmt.Fprintf(out, "       %s\n", p)
+			}
+		}
+		for _, d := range f.sysinfo.Disks() {
+			if d.Live {
+				fmt.Fprintln(out, "  "+i18n.T("prompt.disk-live", d.Path))
+			}
+		}
+		fmt.Fprintln(out, "  m) "+i18n.T("prompt.disk-mounted"))
+
+		answer := strings.TrimSpace(prompt(i18n.T("prompt.disk") + ": "))
+		switch answer {
+		case "":
+			echo(f.config.Layout.Disk)
+			return
+		case "m":
+			echo(i18n.T("prompt.disk-mounted"))
+			f.config.Layout = config.Layout{}
+			return
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(disks) {
+			fmt.Fprintln(out, i18n.T("prompt.disk-choose", len(disks)))
+			continue
+		}
+		echo(disks[n-1].Path)
+		if f.layOut(disks[n-1]) {
+			return
+		}
+	}
+}
+
+// layOut asks how a disk is to be laid out, and returns false if the
+// user changed their mind about it.
+func (f *Frontend) layOut(d sysinfo.Disk) bool {
+	custom := len(layout.Schemes) + 1
+	heading(i18n.T("prompt.layouts"), custom)
+	for n, s := range layout.Schemes {
+		fmt.Fprintf(out, "  %d) %-6s %s\n", n+1, s.Name, i18n.T("layout."+s.Name))
+	}
+	fmt.Fprintf(out, "  %d) %-6s %s\n", custom, "custom", i18n.T("layout.custom"))
+
+	current := "1"
+	for n, s := range layout.Schemes {
+		if s.Name == f.config.Layout.Scheme {
+			current = strconv.Itoa(n + 1)
+		}
+	}
+	n, err := strconv.Atoi(reply(i18n.T("prompt.layout"), current))
+	switch {
+	case err != nil || n < 1 || n > custom:
+		fmt.Fprintln(out, i18n.T("prompt.layout-choose", custom))
+		return false
+	case n == custom:
+		echo("custom")
+		return f.partition(d)
+	}
+	echo(layout.Schemes[n-1].Name)
+
+	l := config.Layout{Disk: d.Path, Scheme: layout.Schemes[n-1].Name}
+	if l.Scheme == "luks" {
+		l.Passphrase = askPassphrase(d.Path)
+	}
+	plan, err := layout.New(l, f.sysinfo.EFI())
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return false
+	}
+
+	fmt.Fprintln(out, i18n.T("prompt.layout-plan", d.Path))
+	for _, line := range plan.Describe() {
+		fmt.Fprintln(out, "  "+line)
+	}
+	if !confirmErase(d.Path, layout.Erases(d)) {
+		return false
+	}
+
+	f.config.Layout = l
+	f.dropFilesystems(d)
+	if f.config.GRUB.InstallTo != "" {
+		f.config.GRUB.InstallTo = d.Path
+	}
+	return true
+}
+
+// askPassphrase asks for the passphrase of an encrypted disk, twice.
+func askPassphrase(disk string) string {
+	for {
+		p := readSecret(i18n.T("prompt.passphrase", disk) + ": ")
+		if p == "" {
+			continue
+		}
+		if weak := shadow.Weaknesses(p); len(weak) > 0 {
+			fmt.Fprintln(out, i18n.T("prompt.passphrase-weak", strings.Join(weak, ", ")))
+			if !askYesNo(i18n.T("prompt.use-anyway"), false) {
+				continue
+			}
+		}
+		if readSecret(i18n.T("prompt.passphrase-again")+": ") != p {
+			fmt.Fprintln(out, i18n.T("prompt.passphrase-mismatch"))
+			continue
+		}
+		return p
+	}
+}
+
+// confirmErase shows what will be lost and asks whether that is
+// really what the user wants, no being the default.
+func confirmErase(what string, lost []string) bool {
+	fmt.Fprintln(out, i18n.T("prompt.erased"))
+	for _, l := range lost {
+		fmt.Fprintln(out, "  "+l)
+	}
+	return askYesNo(i18n.T("prompt.erase", what), false)
+}
+
+// partition hands the disk to cfdisk, then asks where each of the
+// partitions it ends up with is to be mounted.  cfdisk doesn't wait
+// for the installation to be confirmed, so neither does the question
+// of whether what is on the disk may be lost.
+func (f *Frontend) partition(d sysinfo.Disk) bool {
+	fmt.Fprintln(out, i18n.T("prompt.cfdisk", d.Path))
+	if !confirmErase(d.Path, layout.Erases(d)) {
+		return false
+	}
+	cmd := exec.Command("cfdisk", d.Path)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintf(out, "cfdisk: %v\n", err)
+		return false
+	}
+
+	// What cfdisk did is only known by looking again.
+	f.sysinfo = sysinfo.DiscoverHardware()
+	for _, disk := range f.sysinfo.Disks() {
+		if disk.Path == d.Path {
+			d = disk
+		}
+	}
+
+	fs := []config.Filesystem{}
+	lost := []string{}
+	for _, p := range d.Partitions {
+		fmt.Fprintln(out, p)
+		mount := askValid("  "+i18n.T("prompt.mount-point"), p.MountPoint, func(s string) error {
+			if s != "" && s != "swap" && !strings.HasPrefix(s, "/") {
+				return errors.New(i18n.T("prompt.mount-point-absolute"))
+			}
+			return nil
+		})
+		if mount == "" {
+			continue
+		}
+		fsType := p.FSType
+		if mount == "swap" {
+			fsType, mount = "swap", "none"
+		} else if fsType == "" {
+			fsType = "ext4"
+		}
+		fsType = ask("  "+i18n.T("prompt.filesystem-type"), fsType)
+		format := askYesNo("  "+i18n.T("prompt.format", p.Path), p.FSType != fsType)
+
+		fsys := config.Filesystem{FS: p.Path, MountTo: mount, Type: fsType, Options: "defaults", Format: format}
+		switch {
+		case mount == "/":
+			fsys.Pass = 1
+		case fsType != "swap":
+			fsys.Pass = 2
+		}
+		if format {
+			if err := config.ValidateFormat(fsys); err != nil {
+				fmt.Fprintln(out, err)
+				return false
+			}
+			lost = append(lost, p.String())
+		}
+		fs = append(fs, fsys)
+	}
+	if len(lost) > 0 && !confirmErase(i18n.T("prompt.these-partitions"), lost) {
+		return false
+	}
+
+	f.config.Layout = config.Layout{}
+	f.dropFilesystems(d)
+	f.config.Filesystems = append(f.config.Filesystems, fs...)
+	if f.config.GRUB.InstallTo != "" {
+		f.config.GRUB.InstallTo = d.Path
+	}
+	return true
+}
+
+// dropFilesystems forgets the filesystems on a disk, which are about
+// to be replaced.
+func (f *Frontend) dropFilesystems(d sysinfo.Disk) {
+	kept := []config.Filesystem{}
+	for _, fs := range f.config.Filesystems {
+		on := false
+		for _, p := range d.Partitions {
+			on = on || fs.FS == p.Path
+		}
+		if !on {
+			kept = append(kept, fs)
+		}
+	}
+	f.config.Filesystems = kept
+}
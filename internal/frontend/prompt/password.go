@@ -0,0 +1,109 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/shadow"
+)
+
+// password is what was chosen for an account: a password, a hash
+// pasted in, or neither and the account locked.
+type password struct {
+	clear string
+	hash  string
+	lock  bool
+}
+
+func termios(fd uintptr, req uintptr, t *syscall.Termios) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, uintptr(unsafe.Pointer(t)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// readSecret prompts for something that shouldn't be shown, turning
+// off echo if stdin is a terminal.  The terminal is put back even if
+// the installer is interrupted.
+func readSecret(question string) string {
+	fmt.Fprint(out, question)
+	out.Announce()
+	fd := os.Stdin.Fd()
+	var old syscall.Termios
+	if termios(fd, syscall.TCGETS, &old) == nil {
+		quiet := old
+		quiet.Lflag &^= syscall.ECHO
+		quiet.Lflag |= syscall.ECHONL
+		if termios(fd, syscall.TCSETS, &quiet) == nil {
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+			done := make(chan struct{})
+			go func() {
+				select {
+				case <-sigs:
+					termios(fd, syscall.TCSETS, &old)
+					fmt.Fprintln(out)
+					os.Exit(1)
+				case <-done:
+				}
+			}()
+			defer func() {
+				signal.Stop(sigs)
+				close(done)
+				termios(fd, syscall.TCSETS, &old)
+			}()
+		}
+	}
+	text, _ := input.ReadString('\n')
+	return strings.TrimRight(text, "\r\n")
+}
+
+// askPassword asks for the password of an account, twice.  A hash
+// may be pasted instead, or the account locked with !.  Weak
+// passwords are pointed out, names being those of the account.  ok
+// is false if the account already has a password, which was kept.
+func askPassword(account string, set bool, names ...string) (p password, ok bool) {
+	question := i18n.T("prompt.password", account)
+	if set {
+		question = i18n.T("prompt.password-keep", account)
+	}
+	for {
+		answer := readSecret(question + ": ")
+		switch {
+		case answer == "" && set:
+			return password{}, false
+		case answer == "":
+			if askYesNo(i18n.T("prompt.password-none", account), false) {
+				return password{}, true
+			}
+			continue
+		case answer == "!":
+			return password{lock: true}, true
+		case strings.HasPrefix(answer, "$"):
+			if err := config.ValidatePasswordHash(answer); err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			return password{hash: answer}, true
+		}
+
+		if weak := shadow.Weaknesses(answer, names...); len(weak) > 0 {
+			fmt.Fprintln(out, i18n.T("prompt.password-weak", strings.Join(weak, ", ")))
+			if !askYesNo(i18n.T("prompt.use-anyway"), false) {
+				continue
+			}
+		}
+		if readSecret(i18n.T("prompt.password-again")+": ") != answer {
+			fmt.Fprintln(out, i18n.T("prompt.password-mismatch"))
+			continue
+		}
+		return password{clear: answer}, true
+	}
+}
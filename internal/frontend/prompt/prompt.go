@@ -2,13 +2,19 @@ package prompt
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/the-maldridge/vInstaller/internal/config"
 	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/keyboard"
 	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+	"github.com/the-maldridge/vInstaller/internal/timezone"
 )
 
 // Frontend is a simple frontend that just asks questions in a
@@ -24,112 +30,566 @@ func New() (frontend.InstallerFrontend, error) {
 }
 
 func init() {
-	frontend.Register("prompt", New)
+	frontend.Register(frontend.Registration{
+		Name:         "prompt",
+		Description:  "Ask one question after another, works on any terminal",
+		Capabilities: frontend.Interactive | frontend.NeedsTTY | frontend.ScreenReader,
+		Factory:      New,
+	})
 }
 
+// input is shared by every prompt, as a reader of its own for each
+// would lose whatever it had read ahead.
+var input = bufio.NewReader(os.Stdin)
+
 func prompt(prompt string) string {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Print(prompt)
-	text, _ := reader.ReadString('\n')
+	fmt.Fprint(out, prompt)
+	out.Announce()
+	text, _ := input.ReadString('\n')
 	return text
 }
 
-// GetInstallerConfig prompts the user for configuration values.
+// ask prompts with the current value, which is kept if the answer is
+// blank.  A lone - clears it.
+func ask(question, current string) string {
+	answer := reply(question, current)
+	echo(answer)
+	return answer
+}
+
+// reply is ask without reading back the answer, for a caller that
+// can say better what was chosen.
+func reply(question, current string) string {
+	if current != "" {
+		question += " [" + current + "]"
+	}
+	answer := strings.TrimSpace(prompt(question + ": "))
+	switch answer {
+	case "":
+		return current
+	case "-":
+		return ""
+	}
+	return answer
+}
+
+// askValid asks until the answer passes the check.
+func askValid(question, current string, check func(string) error) string {
+	for {
+		answer := reply(question, current)
+		err := check(answer)
+		if err == nil {
+			echo(answer)
+			return answer
+		}
+		fmt.Fprintln(out, err)
+	}
+}
+
+// askYesNo asks a yes or no question, the current answer being the
+// default.  Answers are accepted in English as well as in the
+// language of the installer.
+func askYesNo(question string, current bool) bool {
+	y, n := initial(i18n.T("prompt.yes")), initial(i18n.T("prompt.no"))
+	options := "(" + y + "/" + strings.ToUpper(n) + ")"
+	if current {
+		options = "(" + strings.ToUpper(y) + "/" + n + ")"
+	}
+	for {
+		answer := strings.ToLower(strings.TrimSpace(prompt(question + " " + options + ": ")))
+		yes := current
+		switch {
+		case answer == "":
+		case strings.HasPrefix(answer, y) || strings.HasPrefix(answer, "y"):
+			yes = true
+		case strings.HasPrefix(answer, n) || strings.HasPrefix(answer, "n"):
+			yes = false
+		default:
+			continue
+		}
+		if yes {
+			echo(i18n.T("prompt.yes"))
+		} else {
+			echo(i18n.T("prompt.no"))
+		}
+		return yes
+	}
+}
+
+// initial returns the first letter of a word, in lower case.
+func initial(word string) string {
+	for _, r := range strings.ToLower(word) {
+		return string(r)
+	}
+	return ""
+}
+
+// GetInstallerConfig prompts the user for configuration values, and
+// then lets them revisit any part of it before it is used.
 func (f *Frontend) GetInstallerConfig() (*config.Config, error) {
-	fmt.Println("Welcome to the Void Linux Installer")
-	fmt.Println("")
-	fmt.Println("Please wait while the installer inspects your system...")
-	fmt.Println("")
+	fmt.Fprintln(out, i18n.T("prompt.welcome"))
+	fmt.Fprintln(out, "")
+	fmt.Fprintln(out, i18n.T("prompt.inspecting"))
+	fmt.Fprintln(out, "")
 	f.sysinfo = sysinfo.DiscoverHardware()
 
-	fmt.Println(f.sysinfo)
-	fmt.Println("")
+	fmt.Fprintln(out, f.sysinfo)
+	fmt.Fprintln(out, "")
+	fmt.Fprintln(out, i18n.T("prompt.defaults-help"))
+	fmt.Fprintln(out, "")
 
-	f.config = new(config.Config)
-
-	f.promptTimeZone()
-	f.promptLocale()
-	f.promptGRUB()
-	f.promptKeyboard()
-	f.promptRootPassword()
-	f.promptUsers()
+	f.config = f.defaults()
+	for _, s := range f.sections() {
+		if s.skip != "" && !askYesNo(s.skip, false) {
+			continue
+		}
+		s.prompt()
+	}
 
-	fmt.Println(f.config)
+	f.review()
 	return f.config, nil
 }
 
+// defaults returns the configuration the questions start from.
+func (f *Frontend) defaults() *config.Config {
+	c := new(config.Config)
+	if disk := f.firstDisk(); disk != "" {
+		c.GRUB.InstallTo = disk
+		c.GRUB.UseGraphical = true
+	}
+	return c
+}
+
+// firstDisk returns the disk GRUB is installed to unless the user
+// says otherwise, which is never the live medium.
+func (f *Frontend) firstDisk() string {
+	disks := f.sysinfo.InstallableDisks()
+	if len(disks) == 0 {
+		return ""
+	}
+	return disks[0].Path
+}
+
 // ConfirmInstallation confirms that the user is ready to proceed with
-// potentially destructive actions.
+// potentially destructive actions.  Nothing but the whole word yes,
+// in English or the language of the installer, will do.
 func (f *Frontend) ConfirmInstallation() error {
-	fmt.Println("Do you wish to proceed with installation?")
-	proceed := strings.TrimSpace(prompt("Proceed (yes/no): "))
-	if proceed == "yes" {
+	fmt.Fprintln(out, i18n.T("prompt.proceed-question"))
+	proceed := strings.ToLower(strings.TrimSpace(prompt(i18n.T("prompt.proceed") + ": ")))
+	if proceed == "yes" || proceed == strings.ToLower(i18n.T("prompt.yes")) {
 		return nil
 	}
 	return frontend.ErrInstallationAborted
 }
 
 // ShowInstallationProgress shows the output of the installation.
-func (f *Frontend) ShowInstallationProgress(output <-chan string, errors <-chan error, done <-chan bool) {
+func (f *Frontend) ShowInstallationProgress(output <-chan i18n.Message, errors <-chan error, done <-chan bool) {
 	poll := true
 	for poll {
 		select {
 		case o := <-output:
-			fmt.Println(o)
+			showMessage(o)
 		case e := <-errors:
-			fmt.Println(e)
-		case <- done:
+			fmt.Fprintln(out, e)
+			out.Announce()
+		case <-done:
 			poll = false
 		}
 	}
+
+	// The installer reports the state of the target after it
+	// has finished, so print everything until it hangs up.
+	for o := range output {
+		showMessage(o)
+	}
+	for e := range errors {
+		fmt.Fprintln(out, e)
+		out.Announce()
+	}
+}
+
+// showMessage prints a message from the installer.  Only the
+// installer's own messages are read aloud, the output of the commands
+// it runs would be far too much to listen to.
+func showMessage(o i18n.Message) {
+	fmt.Fprintln(out, o)
+	if o.ID == "" {
+		out.Discard()
+		return
+	}
+	out.Announce()
 }
 
 func (f *Frontend) promptTimeZone() {
-	f.config.TimeZone = prompt("Enter your timezone: ")
+	zones, err := timezone.List("/")
+	if err != nil || len(zones) == 0 {
+		// Without a database to browse all that can be done
+		// is take the user at their word.
+		f.config.TimeZone = ask(i18n.T("prompt.timezone"), f.config.TimeZone)
+		return
+	}
+
+	for {
+		answer := reply(i18n.T("prompt.timezone-browse"), f.config.TimeZone)
+		switch {
+		case answer == "?":
+			other := i18n.T("prompt.timezone-other")
+			region := choose(i18n.T("prompt.timezone-regions"), append(timezone.Regions(zones), other))
+			if region == other {
+				region = ""
+			}
+			city := choose(i18n.T("prompt.timezone-zones"), timezone.Cities(zones, region))
+			if region != "" && city != "" {
+				city = region + "/" + city
+			}
+			answer = city
+		case strings.HasPrefix(answer, "/"):
+			matches := timezone.Search(zones, strings.TrimPrefix(answer, "/"))
+			if len(matches) > 20 {
+				matches = matches[:20]
+			}
+			if len(matches) == 0 {
+				fmt.Fprintln(out, i18n.T("prompt.timezone-none"))
+				continue
+			}
+			answer = choose(i18n.T("prompt.timezone-matching"), matches)
+		}
+		if answer == "" {
+			continue
+		}
+
+		zone, err := timezone.Canonicalize(zones, answer)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		echo(zone)
+		f.config.TimeZone = zone
+		return
+	}
+}
+
+// choose presents a numbered list and returns the chosen item, or an
+// empty string if nothing was chosen.
+func choose(title string, items []string) string {
+	heading(title, len(items))
+	for i, item := range items {
+		fmt.Fprintf(out, "  %3d) %s\n", i+1, item)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(prompt(i18n.T("prompt.choice") + ": ")))
+	if err != nil || n < 1 || n > len(items) {
+		echo("")
+		return ""
+	}
+	echo(items[n-1])
+	return items[n-1]
 }
 
 func (f *Frontend) promptLocale() {
-	f.config.Locale = prompt("Please enter your GLibC Locale: ")
+	f.config.Locale = askValid(i18n.T("prompt.locale"), f.config.Locale, config.ValidateLocale)
 }
 
 func (f *Frontend) promptGRUB() {
-	useGrub := strings.TrimSpace(prompt("Use GRUB? (Y/n): "))
-	if useGrub == "" || strings.Contains(strings.ToLower(useGrub), "y") {
-		graphical := strings.TrimSpace(prompt("Use graphical GRUB? (Y/n): "))
-		if graphical == "" || strings.Contains(strings.ToLower(graphical), "y") {
-			f.config.GRUB.UseGraphical = true
-		}
-		target := strings.TrimSpace(prompt(fmt.Sprintf("Install GRUB to: (/dev/%s)", f.sysinfo.Blk.Disks[0].Name)))
-		if target == "" {
-			f.config.GRUB.InstallTo = "/dev/" + f.sysinfo.Blk.Disks[0].Name
+	if !askYesNo(i18n.T("prompt.grub"), f.config.GRUB.InstallTo != "") {
+		f.config.GRUB.InstallTo = ""
+		f.config.GRUB.UseGraphical = false
+		return
+	}
+	f.config.GRUB.UseGraphical = askYesNo(i18n.T("prompt.grub-graphical"), f.config.GRUB.UseGraphical)
+	target := f.config.GRUB.InstallTo
+	if target == "" {
+		target = f.config.Layout.Disk
+	}
+	if target == "" {
+		target = f.firstDisk()
+	}
+	f.config.GRUB.InstallTo = askValid(i18n.T("prompt.grub-disk"), target, func(s string) error {
+		if s == "" {
+			return errors.New(i18n.T("prompt.grub-no-disk"))
+		}
+		return nil
+	})
+}
+
+func (f *Frontend) promptKeyboard() {
+	keymaps, _ := keyboard.Keymaps("/")
+	for {
+		answer := reply(i18n.T("prompt.keyboard"), f.config.Keyboard)
+		switch {
+		case answer == "?" || strings.HasPrefix(answer, "/"):
+			query := strings.TrimPrefix(strings.TrimPrefix(answer, "?"), "/")
+			matches := []string{}
+			for _, k := range keymaps {
+				if strings.Contains(k, query) {
+					matches = append(matches, k)
+				}
+			}
+			columns("", matches)
+			continue
+		}
+		if err := config.ValidateKeymap(answer); err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		echo(answer)
+		f.config.Keyboard = answer
+		return
+	}
+}
+
+// columns prints a list of short items compactly, or one to a line
+// in accessibility mode so that a screen reader reads them one by
+// one.
+func columns(title string, items []string) {
+	heading(title, len(items))
+	if frontend.Accessible() {
+		for _, item := range items {
+			fmt.Fprintln(out, "  "+item)
+		}
+		return
+	}
+	const width = 20
+	for i, item := range items {
+		fmt.Fprintf(out, "%-*s", width, item)
+		if i%4 == 3 || i == len(items)-1 {
+			fmt.Fprintln(out)
+		}
+	}
+}
+
+func (f *Frontend) promptPackages() {
+	xorg := false
+	extra := []string{}
+	for _, p := range f.config.Packages {
+		if p == "xorg" {
+			xorg = true
+			continue
+		}
+		extra = append(extra, p)
+	}
+
+	f.config.Packages = nil
+	if askYesNo(i18n.T("prompt.xorg"), xorg) {
+		f.config.Packages = append(f.config.Packages, "xorg")
+	}
+	more := ask(i18n.T("prompt.packages"), strings.Join(extra, " "))
+	f.config.Packages = append(f.config.Packages, strings.Fields(more)...)
+}
+
+// keep shows what is configured already and asks whether to keep it.
+// There is nothing to ask about if the list is empty.
+func keep(title string, items []string) bool {
+	if len(items) == 0 {
+		return true
+	}
+	heading(title, len(items))
+	for _, item := range items {
+		fmt.Fprintln(out, "  "+item)
+	}
+	return askYesNo(i18n.T("prompt.keep"), true)
+}
+
+// promptNetwork asks how the installed system should get online,
+// offering the interfaces found on this machine.
+func (f *Frontend) promptNetwork() {
+	f.config.Network.Manager = askValid(i18n.T("prompt.network-manager"), f.config.NetworkManager(), func(s string) error {
+		return config.ValidateNetworkManager(normalizeManager(s))
+	})
+	f.config.Network.Manager = normalizeManager(f.config.Network.Manager)
+	if f.config.Network.Manager == "NetworkManager" {
+		// NetworkManager is set up after installation, so
+		// anything configured here would be ignored.
+		f.config.Network.Interfaces = nil
+		f.config.Network.DNS = nil
+		f.config.Network.Wifi = nil
+		return
+	}
+
+	configured := []string{}
+	for _, iface := range f.config.Network.Interfaces {
+		addrs := "DHCP"
+		if len(iface.Addresses) > 0 {
+			addrs = strings.Join(iface.Addresses, " ")
+		}
+		configured = append(configured, iface.Name+": "+addrs)
+	}
+	if !keep(i18n.T("prompt.interfaces-configured"), configured) {
+		f.config.Network.Interfaces = nil
+	}
+
+	ifaces := f.sysinfo.Interfaces()
+	if len(ifaces) > 0 {
+		columns(i18n.T("prompt.interfaces"), ifaces)
+	}
+	for {
+		name := strings.TrimSpace(prompt(i18n.T("prompt.interface") + ": "))
+		if name == "" {
+			break
+		}
+		iface := config.Interface{Name: name}
+		addr := askValid(i18n.T("prompt.address"), "", func(s string) error {
+			if s == "" {
+				return nil
+			}
+			return config.ValidateInterface(config.Interface{Name: name, Addresses: []string{s}})
+		})
+		if addr != "" {
+			iface.Addresses = []string{addr}
+			iface.Gateway = askValid(i18n.T("prompt.gateway"), "", func(s string) error {
+				if s == "" {
+					return nil
+				}
+				return config.ValidateInterface(config.Interface{Name: name, Gateway: s})
+			})
+		}
+		if err := config.ValidateInterface(iface); err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		f.config.Network.Interfaces = append(f.config.Network.Interfaces, iface)
+	}
+
+	dns := askValid(i18n.T("prompt.dns"), strings.Join(f.config.Network.DNS, " "), func(s string) error {
+		for _, d := range strings.Fields(s) {
+			if err := config.ValidateIP(d); err != nil {
+				return fmt.Errorf("%s: %v", d, err)
+			}
+		}
+		return nil
+	})
+	f.config.Network.DNS = strings.Fields(dns)
+
+	ssids := []string{}
+	for _, w := range f.config.Network.Wifi {
+		ssids = append(ssids, w.SSID)
+	}
+	if !keep(i18n.T("prompt.wifi-configured"), ssids) {
+		f.config.Network.Wifi = nil
+	}
+	for {
+		ssid := strings.TrimSuffix(prompt(i18n.T("prompt.wifi")+": "), "\n")
+		if ssid == "" {
 			return
 		}
-		f.config.GRUB.InstallTo = target
+		w := config.Wifi{SSID: ssid}
+		w.PSK = strings.TrimSuffix(prompt(i18n.T("prompt.wifi-passphrase")+": "), "\n")
+		if err := config.ValidateWifi(w); err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		f.config.Network.Wifi = append(f.config.Network.Wifi, w)
 	}
 }
 
-func (f *Frontend) promptKeyboard() {
-	f.config.Keyboard = prompt("Please enter your keyboard layout")
+// normalizeManager accepts the network managers in any case.
+func normalizeManager(s string) string {
+	for _, m := range []string{"dhcpcd", "NetworkManager"} {
+		if strings.EqualFold(s, m) {
+			return m
+		}
+	}
+	return s
 }
 
-func (f *Frontend) promptHostname() {
-	f.config.Hostname = prompt("System Hostname: ")
+// promptConsole asks for the settings most people never need to
+// change.
+func (f *Frontend) promptConsole() {
+	f.config.HardwareClock = askValid(i18n.T("prompt.clock"), f.config.HardwareClock, func(s string) error {
+		return config.ValidateHardwareClock(normalizeClock(s))
+	})
+	f.config.HardwareClock = normalizeClock(f.config.HardwareClock)
+	f.config.Font = askValid(i18n.T("prompt.font"), f.config.Font, config.ValidateFont)
+	f.config.FontMap = askValid(i18n.T("prompt.font-map"), f.config.FontMap, config.ValidateFontMap)
+	f.config.FontUnimap = askValid(i18n.T("prompt.font-unimap"), f.config.FontUnimap, config.ValidateFontUnimap)
+
+	ttys := ""
+	if f.config.TTYS != 0 {
+		ttys = strconv.Itoa(f.config.TTYS)
+	}
+	ttys = askValid(i18n.T("prompt.ttys"), ttys, func(s string) error {
+		if s == "" {
+			return nil
+		}
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		return config.ValidateTTYS(n)
+	})
+	f.config.TTYS, _ = strconv.Atoi(ttys)
+
+	vars := []string{}
+	for k, v := range f.config.RCVars {
+		vars = append(vars, k+"="+v)
+	}
+	sort.Strings(vars)
+	if len(vars) > 0 {
+		heading(i18n.T("prompt.rc-vars"), len(vars))
+		for _, v := range vars {
+			fmt.Fprintln(out, "  "+v)
+		}
+	}
+	for {
+		v := strings.TrimSpace(prompt(i18n.T("prompt.rc-var") + ": "))
+		if v == "" {
+			return
+		}
+		parts := strings.SplitN(v, "=", 2)
+		if len(parts) != 2 {
+			fmt.Fprintln(out, i18n.T("prompt.rc-var-format"))
+			continue
+		}
+		if err := config.ValidateRCVar(parts[0]); err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		if parts[1] == "" {
+			delete(f.config.RCVars, parts[0])
+			continue
+		}
+		if f.config.RCVars == nil {
+			f.config.RCVars = make(map[string]string)
+		}
+		f.config.RCVars[parts[0]] = parts[1]
+	}
 }
 
-func (f *Frontend) promptRootPassword() {
-	f.config.RootPassword = prompt("Root Password: ")
+// normalizeClock accepts utc in any case.
+func normalizeClock(s string) string {
+	if strings.EqualFold(s, "utc") {
+		return "UTC"
+	}
+	return s
 }
 
-func (f *Frontend) promptUsers() {
-	addUsers := strings.TrimSpace(prompt("Do you wish to add a user? (Y/n) "))
-	if addUsers == "" || strings.Contains(addUsers, "y") {
-		u := config.User{
-			Username: strings.TrimSpace(prompt("Username: ")),
-			GECOS:    strings.TrimSpace(prompt("Name for the user: ")),
-			Password: strings.TrimSpace(prompt("Password: ")),
+func (f *Frontend) promptHostname() {
+	f.config.Hostname = askValid(i18n.T("prompt.hostname"), f.config.Hostname, config.ValidateHostname)
+	if strings.Contains(f.config.Hostname, ".") {
+		f.config.Domain = ""
+		return
+	}
+	f.config.Domain = askValid(i18n.T("prompt.domain"), f.config.Domain, config.ValidateDomain)
+}
+
+// promptRootPassword sets, keeps or locks the root password.  Root
+// can only be locked if a user in the wheel group can take its place.
+func (f *Frontend) promptRootPassword() {
+	c := f.config
+	set := c.RootPassword != "" || c.RootPasswordHash != "" || c.LockRoot
+	for {
+		p, ok := askPassword("root", set, "root")
+		if !ok {
+			return
 		}
-		groups := prompt("Additional groups (comma seperated): ")
-		u.Groups = strings.Split(groups, ",")
-		f.config.Users = append(f.config.Users, u)
+		if p.lock && !c.HasWheelUser() {
+			fmt.Fprintln(out, config.ErrLockRootWithoutWheel)
+			continue
+		}
+		c.RootPassword, c.RootPasswordHash, c.LockRoot = p.clear, p.hash, p.lock
+		return
 	}
 }
+
+func (f *Frontend) promptRootShell() {
+	f.config.RootShell = askValid(i18n.T("prompt.root-shell"), f.config.RootShell, config.ValidateShell)
+}
@@ -0,0 +1,231 @@
+package prompt
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// A section is a group of questions that can be answered again from
+// the review.
+type section struct {
+	name string
+
+	// fields are the prefixes of the validation errors that the
+	// section can fix.
+	fields []string
+
+	// skip is asked the first time through, and the section is
+	// skipped unless the answer is yes.  Empty means always ask.
+	skip string
+
+	summary func() string
+	prompt  func()
+}
+
+// sections returns the sections in the order they are first asked.
+func (f *Frontend) sections() []section {
+	c := f.config
+	return []section{
+		{
+			name:   i18n.T("prompt.section-disk"),
+			fields: []string{"Layout", "Filesystems"},
+			summary: func() string {
+				if c.Layout.Disk != "" {
+					return i18n.T("prompt.summary-layout", c.Layout.Disk, c.Layout.Scheme)
+				}
+				out := []string{}
+				for _, fs := range c.Filesystems {
+					if strings.HasPrefix(fs.MountTo, "/") {
+						out = append(out, i18n.T("prompt.summary-filesystem", fs.FS, fs.MountTo))
+					}
+				}
+				if len(out) == 0 {
+					return i18n.T("prompt.summary-mounted")
+				}
+				return strings.Join(out, ", ")
+			},
+			prompt: f.promptDisks,
+		},
+		{
+			name:    i18n.T("prompt.section-hostname"),
+			fields:  []string{"Hostname", "Domain", "Hosts"},
+			summary: func() string { return c.FQDN() },
+			prompt:  f.promptHostname,
+		},
+		{
+			name:    i18n.T("prompt.section-timezone"),
+			fields:  []string{"TimeZone"},
+			summary: func() string { return c.TimeZone },
+			prompt:  f.promptTimeZone,
+		},
+		{
+			name:    i18n.T("prompt.section-locale"),
+			fields:  []string{"Locale"},
+			summary: func() string { return c.Locale },
+			prompt:  f.promptLocale,
+		},
+		{
+			name:   i18n.T("prompt.section-bootloader"),
+			fields: []string{"GRUB"},
+			summary: func() string {
+				switch {
+				case c.GRUB.InstallTo == "":
+					return i18n.T("prompt.summary-no-grub")
+				case c.GRUB.UseGraphical:
+					return i18n.T("prompt.summary-grub-graphical", c.GRUB.InstallTo)
+				}
+				return i18n.T("prompt.summary-grub", c.GRUB.InstallTo)
+			},
+			prompt: f.promptGRUB,
+		},
+		{
+			name:    i18n.T("prompt.section-keyboard"),
+			fields:  []string{"Keyboard"},
+			summary: func() string { return c.Keyboard },
+			prompt:  f.promptKeyboard,
+		},
+		{
+			name:    i18n.T("prompt.section-packages"),
+			fields:  []string{"Packages"},
+			summary: func() string { return strings.Join(c.Packages, " ") },
+			prompt:  f.promptPackages,
+		},
+		{
+			name:   i18n.T("prompt.section-network"),
+			fields: []string{"Network"},
+			summary: func() string {
+				out := c.NetworkManager()
+				for _, iface := range c.Network.Interfaces {
+					out += ", " + iface.Name
+				}
+				for _, w := range c.Network.Wifi {
+					out += ", " + i18n.T("prompt.summary-wifi", w.SSID)
+				}
+				return out
+			},
+			prompt: f.promptNetwork,
+		},
+		{
+			name:   i18n.T("prompt.section-console"),
+			fields: []string{"HardwareClock", "Font", "FontMap", "FontUnimap", "TTYS", "RCVars"},
+			skip:   i18n.T("prompt.console-skip"),
+			summary: func() string {
+				out := []string{}
+				if c.HardwareClock != "" {
+					out = append(out, i18n.T("prompt.summary-clock", c.HardwareClock))
+				}
+				if c.Font != "" {
+					out = append(out, i18n.T("prompt.summary-font", c.Font))
+				}
+				if c.TTYS != 0 {
+					out = append(out, i18n.T("prompt.summary-ttys", c.TTYS))
+				}
+				if len(c.RCVars) > 0 {
+					out = append(out, i18n.T("prompt.summary-rc-vars", len(c.RCVars)))
+				}
+				if len(out) == 0 {
+					return i18n.T("prompt.summary-defaults")
+				}
+				return strings.Join(out, ", ")
+			},
+			prompt: f.promptConsole,
+		},
+		{
+			name:   i18n.T("prompt.section-users"),
+			fields: []string{"Users", "Privilege"},
+			summary: func() string {
+				names := []string{}
+				for _, u := range c.Users {
+					names = append(names, u.Username)
+				}
+				return strings.Join(names, ", ")
+			},
+			prompt: f.promptUsers,
+		},
+		{
+			name:   i18n.T("prompt.section-root-password"),
+			fields: []string{"RootPassword", "RootPasswordHash", "LockRoot"},
+			summary: func() string {
+				if c.LockRoot {
+					return i18n.T("prompt.summary-locked")
+				}
+				if c.RootPassword == "" && c.RootPasswordHash == "" {
+					return i18n.T("prompt.summary-not-set")
+				}
+				return i18n.T("prompt.summary-set")
+			},
+			prompt: f.promptRootPassword,
+		},
+		{
+			name:    i18n.T("prompt.section-root-shell"),
+			fields:  []string{"RootShell"},
+			summary: func() string { return c.RootShell },
+			prompt:  f.promptRootShell,
+		},
+	}
+}
+
+// covers reports whether the section can fix the field.
+func (s section) covers(field string) bool {
+	for _, prefix := range s.fields {
+		if field == prefix || strings.HasPrefix(field, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// review lists the sections and lets any of them be answered again,
+// until the user is happy and the configuration is valid.
+func (f *Frontend) review() {
+	sections := f.sections()
+	for {
+		var errs config.ValidationErrors
+		if err := f.config.Validate(); err != nil {
+			if v, ok := err.(config.ValidationErrors); ok {
+				errs = v
+			} else {
+				errs = config.ValidationErrors{{Field: "", Err: err}}
+			}
+		}
+
+		fmt.Fprintln(out, "")
+		heading(i18n.T("prompt.review"), len(sections))
+		shown := make([]bool, len(errs))
+		for n, s := range sections {
+			fmt.Fprintf(out, "  %2d) %-14s %s\n", n+1, s.name+":", s.summary())
+			for i, e := range errs {
+				if !shown[i] && s.covers(e.Field) {
+					fmt.Fprintf(out, "      ! %v\n", e.Err)
+					shown[i] = true
+				}
+			}
+		}
+		for i, e := range errs {
+			if !shown[i] {
+				fmt.Fprintf(out, "      ! %v\n", e)
+			}
+		}
+		fmt.Fprintln(out, "")
+
+		answer := strings.TrimSpace(prompt(i18n.T("prompt.review-section") + ": "))
+		if answer == "" {
+			if len(errs) == 0 {
+				return
+			}
+			fmt.Fprintln(out, i18n.T("prompt.review-problems"))
+			continue
+		}
+		n, err := strconv.Atoi(answer)
+		if err != nil || n < 1 || n > len(sections) {
+			fmt.Fprintln(out, i18n.T("prompt.review-choose", len(sections)))
+			continue
+		}
+		echo(sections[n-1].name)
+		sections[n-1].prompt()
+	}
+}
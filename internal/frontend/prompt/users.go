@@ -0,0 +1,182 @@
+package prompt
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// promptUsers lists the users and lets them be added, edited and
+// removed until the list is right.
+func (f *Frontend) promptUsers() {
+	for {
+		if len(f.config.Users) == 0 {
+			if !askYesNo(i18n.T("prompt.user-add"), true) {
+				return
+			}
+			f.addUser()
+			continue
+		}
+
+		heading(i18n.T("prompt.users"), len(f.config.Users))
+		for n, u := range f.config.Users {
+			fmt.Fprintf(out, "  %d) %s (%s) %s\n", n+1, u.Username, u.GECOS, strings.Join(u.Groups, ","))
+		}
+		answer := strings.TrimSpace(prompt(i18n.T("prompt.users-edit") + ": "))
+		switch {
+		case answer == "":
+			return
+		case answer == "a":
+			f.addUser()
+			continue
+		case strings.HasPrefix(answer, "d"):
+			if n, ok := f.userIndex(strings.TrimSpace(answer[1:])); ok {
+				u := f.config.Users[n]
+				if askYesNo(i18n.T("prompt.user-delete", u.Username), false) {
+					f.config.Users = append(f.config.Users[:n], f.config.Users[n+1:]...)
+				}
+			}
+			continue
+		}
+		if n, ok := f.userIndex(answer); ok {
+			f.editUser(n)
+		}
+	}
+}
+
+// userIndex turns a number from the list of users into an index.
+func (f *Frontend) userIndex(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > len(f.config.Users) {
+		fmt.Fprintln(out, i18n.T("prompt.users-choose", len(f.config.Users)))
+		return 0, false
+	}
+	return n - 1, true
+}
+
+// addUser adds a user, in the wheel group unless they say otherwise.
+func (f *Frontend) addUser() {
+	f.config.Users = append(f.config.Users, config.User{Groups: []string{"wheel"}})
+	f.editUser(len(f.config.Users) - 1)
+}
+
+// editUser asks about the user at index n, with what is already set
+// as the defaults.
+func (f *Frontend) editUser(n int) {
+	u := &f.config.Users[n]
+	u.Username = askValid(i18n.T("prompt.username"), u.Username, func(s string) error {
+		if err := config.ValidateUsername(s); err != nil {
+			return err
+		}
+		for m, other := range f.config.Users {
+			if m != n && other.Username == s {
+				return config.ErrDuplicateUser
+			}
+		}
+		return nil
+	})
+	u.GECOS = ask(i18n.T("prompt.gecos"), u.GECOS)
+
+	set := u.Password != "" || u.PasswordHash != "" || u.Lock
+	if p, ok := askPassword(u.Username, set, u.Username, u.GECOS); ok {
+		u.Password, u.PasswordHash, u.Lock = p.clear, p.hash, p.lock
+	}
+
+	u.Groups = chooseGroups(u.Groups)
+	u.Shell = askValid(i18n.T("prompt.shell"), u.Shell, config.ValidateShell)
+
+	if !keep(i18n.T("prompt.ssh-keys"), append(append([]string{}, u.SSHKeyFiles...), u.SSHKeys...)) {
+		u.SSHKeys, u.SSHKeyFiles = nil, nil
+	}
+	f.promptSSHKeys(u)
+}
+
+// chooseGroups presents the common groups, and any others the user
+// is in, as a checklist.  Other groups can be added by name.
+func chooseGroups(current []string) []string {
+	names := []string{}
+	descriptions := make(map[string]string)
+	for _, g := range config.CommonGroups {
+		names = append(names, g.Name)
+		descriptions[g.Name] = i18n.T("group." + g.Name)
+	}
+	checked := make(map[string]bool)
+	for _, g := range current {
+		if g = strings.TrimSpace(g); g == "" {
+			continue
+		}
+		if _, ok := descriptions[g]; !ok {
+			names = append(names, g)
+			descriptions[g] = ""
+		}
+		checked[g] = true
+	}
+
+	for {
+		heading(i18n.T("prompt.groups"), len(names))
+		for n, g := range names {
+			fmt.Fprintf(out, "  %d) %s %-10s %s\n", n+1, checkbox(checked[g]), g, descriptions[g])
+		}
+		answer := strings.Fields(prompt(i18n.T("prompt.groups-toggle") + ": "))
+		if len(answer) == 0 {
+			break
+		}
+		for _, a := range answer {
+			if strings.HasPrefix(a, "+") {
+				g := strings.TrimPrefix(a, "+")
+				if err := config.ValidateGroup(g); err != nil {
+					fmt.Fprintf(out, "%s: %v\n", g, err)
+					continue
+				}
+				if _, ok := descriptions[g]; !ok {
+					names = append(names, g)
+					descriptions[g] = ""
+				}
+				checked[g] = true
+				continue
+			}
+			n, err := strconv.Atoi(a)
+			if err != nil || n < 1 || n > len(names) {
+				fmt.Fprintln(out, i18n.T("prompt.groups-choose", a, len(names)))
+				continue
+			}
+			checked[names[n-1]] = !checked[names[n-1]]
+		}
+	}
+
+	groups := []string{}
+	for _, g := range names {
+		if checked[g] {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// promptSSHKeys asks for keys to authorize, either pasted in or as
+// files on the live system.
+func (f *Frontend) promptSSHKeys(u *config.User) {
+	for {
+		key := strings.TrimSpace(prompt(i18n.T("prompt.ssh-key") + ": "))
+		if key == "" {
+			return
+		}
+		if _, err := os.Stat(key); err == nil {
+			if err := config.ValidateSSHKeyFile(key); err != nil {
+				fmt.Fprintln(out, err)
+				continue
+			}
+			u.SSHKeyFiles = append(u.SSHKeyFiles, key)
+			continue
+		}
+		if err := config.ValidateSSHKey(key); err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		u.SSHKeys = append(u.SSHKeys, key)
+	}
+}
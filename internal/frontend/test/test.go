@@ -8,6 +8,7 @@ import (
 
 	"github.com/the-maldridge/vInstaller/internal/config"
 	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
 	"github.com/the-maldridge/vInstaller/internal/sysinfo"
 )
 
@@ -24,7 +25,12 @@ func New() (frontend.InstallerFrontend, error) {
 }
 
 func init() {
-	frontend.Register("test", New)
+	frontend.Register(frontend.Registration{
+		Name:         "test",
+		Description:  "Install a fixed configuration, for testing the installer",
+		Capabilities: frontend.Unattended,
+		Factory:      New,
+	})
 }
 
 // GetInstallerConfig fetches config from somewhere else to provide to
@@ -48,12 +54,12 @@ func (f *Frontend) GetInstallerConfig() (*config.Config, error) {
 		},
 		Filesystems: []config.Filesystem{
 			config.Filesystem{
-				FS: "/dev/hda",
+				FS:      "/dev/hda",
 				MountTo: "/",
-				Type: "ext4",
+				Type:    "ext4",
 				Options: "defaults",
-				Dump: 1,
-				Pass: 1,
+				Dump:    1,
+				Pass:    1,
 			},
 		},
 	}
@@ -76,7 +82,7 @@ func (f *Frontend) ConfirmInstallation() error {
 }
 
 // ShowInstallationProgress shows the output of the installation.
-func (f *Frontend) ShowInstallationProgress(output <-chan string, errors <-chan error, done <-chan bool) {
+func (f *Frontend) ShowInstallationProgress(output <-chan i18n.Message, errors <-chan error, done <-chan bool) {
 	poll := true
 	for poll {
 		select {
@@ -88,4 +94,13 @@ func (f *Frontend) ShowInstallationProgress(output <-chan string, errors <-chan
 			poll = false
 		}
 	}
+
+	// The installer reports the state of the target after it
+	// has finished, so print everything until it hangs up.
+	for o := range output {
+		fmt.Println(o)
+	}
+	for e := range errors {
+		fmt.Println(e)
+	}
 }
@@ -0,0 +1,283 @@
+package tui
+
+import (
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/layout"
+	"github.com/the-maldridge/vInstaller/internal/shadow"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+func (f *Frontend) diskSummary() string {
+	c := f.config
+	if c.Layout.Disk != "" {
+		return c.Layout.Disk + ", " + c.Layout.Scheme
+	}
+	n := 0
+	for _, fs := range c.Filesystems {
+		if strings.HasPrefix(fs.MountTo, "/") {
+			n++
+		}
+	}
+	if n == 0 {
+		return i18n.T("tui.disk-mounted")
+	}
+	return i18n.T("tui.filesystems", n)
+}
+
+// editDisk chooses the disk to install to and how it is laid out.
+// The live medium isn't offered.
+func (f *Frontend) editDisk() {
+	disks := f.sysinfo.InstallableDisks()
+	items := []string{}
+	for _, d := range disks {
+		items = append(items, i18n.T("tui.disk-item", d, len(d.Partitions)))
+	}
+	items = append(items, i18n.T("tui.disk-use-mounted"))
+
+	selected := 0
+	for n, d := range disks {
+		if d.Path == f.config.Layout.Disk {
+			selected = n
+		}
+	}
+	n := f.menu(i18n.T("tui.disk-title"), items, selected)
+	switch {
+	case n < 0:
+		return
+	case n == len(disks):
+		f.config.Layout = config.Layout{}
+		return
+	}
+	d := disks[n]
+
+	schemes := []string{}
+	selected = 0
+	for n, s := range layout.Schemes {
+		schemes = append(schemes, padRight(s.Name, 8)+i18n.T("layout."+s.Name))
+		if s.Name == f.config.Layout.Scheme {
+			selected = n
+		}
+	}
+	schemes = append(schemes, padRight("custom", 8)+i18n.T("layout.custom"))
+	n = f.menu(i18n.T("tui.layout-title", d.Path), schemes, selected)
+	switch {
+	case n < 0:
+		return
+	case n == len(layout.Schemes):
+		f.partition(d)
+		return
+	}
+
+	l := config.Layout{Disk: d.Path, Scheme: layout.Schemes[n].Name}
+	if l.Scheme == "luks" {
+		var ok bool
+		if l.Passphrase, ok = f.passphrase(d.Path); !ok {
+			return
+		}
+	}
+	plan, err := layout.New(l, f.sysinfo.EFI())
+	if err != nil {
+		f.message(i18n.T("tui.section-disk"), err.Error())
+		return
+	}
+	text := i18n.T("tui.layout-plan", d.Path) + "\n\n"
+	for _, line := range plan.Describe() {
+		text += "  " + line + "\n"
+	}
+	if !f.confirmErase(d.Path, text, layout.Erases(d)) {
+		return
+	}
+
+	f.config.Layout = l
+	f.dropFilesystems(d)
+	if f.config.GRUB.InstallTo != "" {
+		f.config.GRUB.InstallTo = d.Path
+	}
+}
+
+// passphrase asks for the passphrase of an encrypted disk, twice.
+// ok is false if the user backed out.
+func (f *Frontend) passphrase(disk string) (string, bool) {
+	title := i18n.T("tui.encryption")
+	for {
+		p, ok := f.input(title, i18n.T("tui.passphrase-label", disk), "", true, nil)
+		if !ok {
+			return "", false
+		}
+		if p == "" {
+			continue
+		}
+		if weak := shadow.Weaknesses(p); len(weak) > 0 {
+			if !f.yesNo(title, i18n.T("tui.passphrase-weak", strings.Join(weak, ", "))) {
+				continue
+			}
+		}
+		again, ok := f.input(title, i18n.T("tui.passphrase-again"), "", true, nil)
+		if !ok {
+			return "", false
+		}
+		if p == again {
+			return p, true
+		}
+		f.message(title, i18n.T("tui.passphrase-mismatch"))
+	}
+}
+
+// confirmErase shows what will be lost after text and asks whether
+// that is really what the user wants.
+func (f *Frontend) confirmErase(what, text string, lost []string) bool {
+	text += "\n" + i18n.T("tui.erased") + "\n\n"
+	for _, l := range lost {
+		text += "  " + l + "\n"
+	}
+	return f.yesNo(i18n.T("tui.erase-title", what), text+"\n"+i18n.T("tui.erase", what))
+}
+
+// partition hands the disk to cfdisk, then lets the user say where
+// each partition it ends up with is mounted.  cfdisk doesn't wait for
+// the installation to be confirmed, so neither does the question of
+// whether what is on the disk may be lost.
+func (f *Frontend) partition(d sysinfo.Disk) {
+	if !f.confirmErase(d.Path, i18n.T("tui.cfdisk", d.Path)+"\n", layout.Erases(d)) {
+		return
+	}
+	if err := f.runOutside(exec.Command("cfdisk", d.Path)); err != nil {
+		f.message(i18n.T("tui.section-disk"), "cfdisk: "+err.Error())
+		return
+	}
+
+	// What cfdisk did is only known by looking again.
+	f.sysinfo = sysinfo.DiscoverHardware()
+	for _, disk := range f.sysinfo.Disks() {
+		if disk.Path == d.Path {
+			d = disk
+		}
+	}
+
+	fs := make([]config.Filesystem, len(d.Partitions))
+	for n, p := range d.Partitions {
+		fs[n] = config.Filesystem{FS: p.Path, MountTo: p.MountPoint, Type: p.FSType, Options: "defaults"}
+	}
+	f.form(i18n.T("tui.partitions-title", d.Path), func() []field {
+		fields := []field{}
+		for n, p := range d.Partitions {
+			n, p := n, p
+			value := i18n.T("tui.not-used")
+			if fs[n].MountTo != "" {
+				value = fs[n].MountTo + " " + fs[n].Type
+				if fs[n].Format {
+					value += ", " + i18n.T("tui.formatted")
+				}
+			}
+			fields = append(fields, field{p.String(), value, func() {
+				f.editPartition(p, &fs[n])
+			}})
+		}
+		return append(fields, field{i18n.T("tui.done"), "", nil})
+	})
+
+	used := []config.Filesystem{}
+	lost := []string{}
+	for n, p := range d.Partitions {
+		if fs[n].MountTo == "" {
+			continue
+		}
+		if fs[n].Format {
+			lost = append(lost, p.String())
+		}
+		used = append(used, fs[n])
+	}
+	if len(lost) > 0 && !f.confirmErase(i18n.T("tui.partitions"), "", lost) {
+		return
+	}
+
+	f.config.Layout = config.Layout{}
+	f.dropFilesystems(d)
+	f.config.Filesystems = append(f.config.Filesystems, used...)
+	if f.config.GRUB.InstallTo != "" {
+		f.config.GRUB.InstallTo = d.Path
+	}
+}
+
+// editPartition asks where a partition is mounted, as what, and
+// whether it is formatted first.
+func (f *Frontend) editPartition(p sysinfo.Partition, fs *config.Filesystem) {
+	title := p.Path
+	mount := fs.MountTo
+	if fs.Type == "swap" {
+		mount = "swap"
+	}
+	mount, ok := f.input(title, i18n.T("tui.mount-label"), mount, false, func(s string) error {
+		if s != "" && s != "swap" && !strings.HasPrefix(s, "/") {
+			return errors.New(i18n.T("tui.mount-absolute"))
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+	switch mount = strings.TrimSpace(mount); mount {
+	case "":
+		fs.MountTo, fs.Format = "", false
+		return
+	case "swap":
+		fs.MountTo, fs.Type = "none", "swap"
+	default:
+		fs.MountTo = mount
+		if fs.Type == "" || fs.Type == "swap" {
+			fs.Type = "ext4"
+		}
+		f.editChoice(i18n.T("tui.filesystem-title", p.Path), config.FilesystemTypes, &fs.Type)
+	}
+	fs.Format = p.FSType != fs.Type || f.yesNo(title, i18n.T("tui.format", p.Path))
+	switch {
+	case fs.MountTo == "/":
+		fs.Pass = 1
+	case fs.Type == "swap":
+		fs.Pass = 0
+	default:
+		fs.Pass = 2
+	}
+}
+
+// dropFilesystems forgets the filesystems on a disk, which are about
+// to be replaced.
+func (f *Frontend) dropFilesystems(d sysinfo.Disk) {
+	kept := []config.Filesystem{}
+	for _, fs := range f.config.Filesystems {
+		on := false
+		for _, p := range d.Partitions {
+			on = on || fs.FS == p.Path
+		}
+		if !on {
+			kept = append(kept, fs)
+		}
+	}
+	f.config.Filesystems = kept
+}
+
+// runOutside gives the terminal to cmd while it runs, and takes it
+// back afterwards.
+func (f *Frontend) runOutside(cmd *exec.Cmd) error {
+	f.screen.Fini()
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err := cmd.Run()
+
+	s, serr := f.newScreen()
+	if serr == nil {
+		serr = s.Init()
+	}
+	if serr != nil {
+		log.Printf("Could not restart the terminal UI: %v", serr)
+		return serr
+	}
+	f.screen = s
+	return err
+}
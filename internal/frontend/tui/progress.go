@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gdamore/tcell"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/installer"
+)
+
+// logLine is a line of the installation log.
+type logLine struct {
+	text string
+	err  bool
+}
+
+// progress is what the installation view shows.
+type progress struct {
+	step, total int
+	name        string
+
+	lines  []logLine
+	offset int
+	follow bool
+
+	finished bool
+	failed   bool
+}
+
+// ShowInstallationProgress shows a progress bar above the log of the
+// installation.  The log can be scrolled, and Ctrl-C cancels the
+// installation, which the installer then cleans up after.
+func (f *Frontend) ShowInstallationProgress(output <-chan i18n.Message, errors <-chan error, done <-chan bool) {
+	if err := f.start(); err != nil {
+		log.Printf("Could not start the terminal UI: %v", err)
+		printProgress(output, errors, done)
+		return
+	}
+	defer f.stop()
+
+	events := make(chan tcell.Event, 10)
+	go func() {
+		for {
+			ev := f.screen.PollEvent()
+			if ev == nil {
+				return
+			}
+			events <- ev
+		}
+	}()
+
+	p := &progress{follow: true}
+	for {
+		f.drawProgress(p)
+		f.screen.Show()
+
+		select {
+		case o, ok := <-output:
+			if !ok {
+				output = nil
+				break
+			}
+			if n, total, name, ok := installer.ParseProgress(o); ok {
+				p.step, p.total, p.name = n, total, name
+			}
+			p.lines = append(p.lines, logLine{text: o.String()})
+		case e, ok := <-errors:
+			if !ok {
+				errors = nil
+				break
+			}
+			p.failed = true
+			p.lines = append(p.lines, logLine{text: e.Error(), err: true})
+		case <-done:
+			done = nil
+			p.finished = true
+		case ev := <-events:
+			if f.progressKey(p, ev) {
+				return
+			}
+		}
+	}
+}
+
+// printProgress prints the output of the installation line by line,
+// for when there is no screen to draw it on.  Everything is read
+// until the installer hangs up, as it blocks on sending to a channel
+// nobody reads.
+func printProgress(output <-chan i18n.Message, errors <-chan error, done <-chan bool) {
+	poll := true
+	for poll {
+		select {
+		case o := <-output:
+			fmt.Println(o)
+		case e := <-errors:
+			fmt.Println(e)
+		case <-done:
+			poll = false
+		}
+	}
+
+	for o := range output {
+		fmt.Println(o)
+	}
+	for e := range errors {
+		fmt.Println(e)
+	}
+}
+
+// progressKey handles a key press while installing, and returns true
+// once the user has dismissed the finished installation.
+func (f *Frontend) progressKey(p *progress, ev tcell.Event) bool {
+	switch ev := ev.(type) {
+	case *tcell.EventResize:
+		f.screen.Sync()
+	case *tcell.EventKey:
+		_, h := f.screen.Size()
+		page := h - 6
+		switch ev.Key() {
+		case tcell.KeyUp:
+			p.offset--
+			p.follow = false
+		case tcell.KeyDown:
+			p.offset++
+		case tcell.KeyPgUp:
+			p.offset -= page
+			p.follow = false
+		case tcell.KeyPgDn:
+			p.offset += page
+		case tcell.KeyHome:
+			p.offset = 0
+			p.follow = false
+		case tcell.KeyEnd:
+			p.follow = true
+		case tcell.KeyCtrlC:
+			if !p.finished && f.cancel != nil {
+				p.lines = append(p.lines, logLine{text: i18n.T("tui.cancelling")})
+				f.cancel()
+			}
+		case tcell.KeyEnter, tcell.KeyEscape:
+			return p.finished
+		}
+	}
+	return false
+}
+
+func (f *Frontend) drawProgress(p *progress) {
+	help := i18n.T("tui.help-progress")
+	if p.finished {
+		help = i18n.T("tui.help-finished")
+	}
+	top, bottom, w := f.frame(i18n.T("tui.installing"), help)
+
+	status := i18n.T("tui.preparing")
+	switch {
+	case p.finished && p.failed:
+		status = i18n.T("tui.failed")
+	case p.finished:
+		status = i18n.T("tui.complete")
+	case p.total > 0:
+		status = i18n.T("installer.step", p.step, p.total, p.name)
+	}
+	style := styleNormal
+	if p.failed {
+		style = styleError
+	}
+	f.drawText(2, top, w-4, style, status)
+	f.drawText(2, top+1, w-4, styleNormal, progressBar(p, w-4))
+
+	// The log pane fills the rest of the screen.
+	top += 3
+	rows := bottom - top + 1
+	last := len(p.lines) - rows
+	if last < 0 {
+		last = 0
+	}
+	if p.follow || p.offset > last {
+		p.offset = last
+	}
+	if p.offset >= last {
+		p.follow = true
+	}
+	if p.offset < 0 {
+		p.offset = 0
+	}
+	for row := 0; row < rows && p.offset+row < len(p.lines); row++ {
+		l := p.lines[p.offset+row]
+		style := styleNormal
+		if l.err {
+			style = styleError
+		}
+		f.drawText(2, top+row, w-4, style, l.text)
+	}
+}
+
+// progressBar draws a bar w columns wide showing how many steps are
+// done.  The step being run counts as half done.
+func progressBar(p *progress, w int) string {
+	inner := w - 2
+	if inner < 1 {
+		return ""
+	}
+	filled := 0
+	switch {
+	case p.finished && !p.failed:
+		filled = inner
+	case p.total > 0:
+		filled = inner * (2*p.step - 1) / (2 * p.total)
+	}
+	return "[" + strings.Repeat("=", filled) + strings.Repeat(" ", inner-filled) + "]"
+}
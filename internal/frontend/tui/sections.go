@@ -0,0 +1,730 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/keyboard"
+	"github.com/the-maldridge/vInstaller/internal/locale"
+	"github.com/the-maldridge/vInstaller/internal/shadow"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+	"github.com/the-maldridge/vInstaller/internal/timezone"
+)
+
+// sections are the entries of the main menu, one for each part of
+// the configuration.
+func (f *Frontend) sections() []field {
+	c := f.config
+	return []field{
+		{i18n.T("tui.section-disk"), f.diskSummary(), f.editDisk},
+		{i18n.T("tui.section-hostname"), c.FQDN(), f.editHostname},
+		{i18n.T("tui.section-timezone"), c.TimeZone, f.editTimeZone},
+		{i18n.T("tui.section-locale"), c.Locale, f.editLocale},
+		{i18n.T("tui.section-keyboard"), c.Keyboard, f.editKeyboard},
+		{i18n.T("tui.section-console"), f.consoleSummary(), f.editConsole},
+		{i18n.T("tui.section-bootloader"), f.bootloaderSummary(), f.editBootloader},
+		{i18n.T("tui.section-packages"), summarize(c.Packages), f.editPackages},
+		{i18n.T("tui.section-network"), c.NetworkManager(), f.editNetwork},
+		{i18n.T("tui.section-services"), f.servicesSummary(), f.editServices},
+		{i18n.T("tui.section-root"), f.rootSummary(), f.editRoot},
+		{i18n.T("tui.section-users"), f.usersSummary(), f.editUsers},
+		{i18n.T("tui.section-privileges"), c.PrivilegeTool(), f.editPrivilege},
+	}
+}
+
+// editText lets the user change a single string.
+func (f *Frontend) editText(title, label string, value *string, check func(string) error) {
+	if s, ok := f.input(title, label, *value, false, check); ok {
+		*value = strings.TrimSpace(s)
+	}
+}
+
+// editChoice lets the user set value to one of choices.
+func (f *Frontend) editChoice(title string, choices []string, value *string) {
+	selected := 0
+	for n, c := range choices {
+		if c == *value {
+			selected = n
+		}
+	}
+	if n := f.menu(title, choices, selected); n >= 0 {
+		*value = choices[n]
+	}
+}
+
+func yesNo(b bool) string {
+	if b {
+		return i18n.T("tui.yes")
+	}
+	return i18n.T("tui.no")
+}
+
+func (f *Frontend) editHostname() {
+	f.editText(i18n.T("tui.section-hostname"), i18n.T("tui.hostname-label"), &f.config.Hostname, config.ValidateHostname)
+	if strings.Contains(f.config.Hostname, ".") {
+		f.config.Domain = ""
+		return
+	}
+	f.editText(i18n.T("tui.section-hostname"), i18n.T("tui.domain-label"), &f.config.Domain, config.ValidateDomain)
+}
+
+func (f *Frontend) editTimeZone() {
+	zones, err := timezone.List("/")
+	if err != nil || len(zones) == 0 {
+		// Without a database to browse all that can be done
+		// is take the user at their word.
+		f.editText(i18n.T("tui.section-timezone"), i18n.T("tui.timezone-label"), &f.config.TimeZone, config.ValidateTimeZone)
+		return
+	}
+
+	regions := append(timezone.Regions(zones), i18n.T("tui.timezone-other"))
+	for {
+		current := strings.SplitN(f.config.TimeZone, "/", 2)[0]
+		selected := 0
+		for n, r := range regions {
+			if r == current {
+				selected = n
+			}
+		}
+		n := f.menu(i18n.T("tui.timezone-region"), regions, selected)
+		if n < 0 {
+			return
+		}
+		region := regions[n]
+		if n == len(regions)-1 {
+			region = ""
+		}
+		cities := timezone.Cities(zones, region)
+		selected = 0
+		for n, c := range cities {
+			if region+"/"+c == f.config.TimeZone || c == f.config.TimeZone {
+				selected = n
+			}
+		}
+		n = f.menu(i18n.T("tui.timezone-in", regions[n]), cities, selected)
+		if n < 0 {
+			continue
+		}
+		if region == "" {
+			f.config.TimeZone = cities[n]
+		} else {
+			f.config.TimeZone = region + "/" + cities[n]
+		}
+		return
+	}
+}
+
+func (f *Frontend) editLocale() {
+	locales, err := locale.List("/")
+	if err != nil || len(locales) == 0 {
+		f.editText(i18n.T("tui.section-locale"), i18n.T("tui.locale-label"), &f.config.Locale, nil)
+		return
+	}
+	f.editChoice(i18n.T("tui.section-locale"), locales, &f.config.Locale)
+}
+
+func (f *Frontend) editKeyboard() {
+	keymaps, err := keyboard.Keymaps("/")
+	if err != nil || len(keymaps) == 0 {
+		f.editText(i18n.T("tui.section-keyboard"), i18n.T("tui.keyboard-label"), &f.config.Keyboard, config.ValidateKeymap)
+		return
+	}
+	f.editChoice(i18n.T("tui.section-keyboard"), keymaps, &f.config.Keyboard)
+}
+
+func (f *Frontend) consoleSummary() string {
+	c := f.config
+	if c.HardwareClock == "" && c.Font == "" && c.TTYS == 0 && len(c.RCVars) == 0 {
+		return i18n.T("tui.defaults")
+	}
+	return i18n.T("tui.customized")
+}
+
+func (f *Frontend) editConsole() {
+	c := f.config
+	f.form(i18n.T("tui.section-console"), func() []field {
+		ttys := i18n.T("tui.default")
+		if c.TTYS != 0 {
+			ttys = strconv.Itoa(c.TTYS)
+		}
+		return []field{
+			{i18n.T("tui.clock"), c.HardwareClock, func() {
+				f.editChoice(i18n.T("tui.clock"), []string{"UTC", "localtime"}, &c.HardwareClock)
+			}},
+			{i18n.T("tui.font"), c.Font, func() {
+				f.editText(i18n.T("tui.font"), i18n.T("tui.font-label"), &c.Font, config.ValidateFont)
+			}},
+			{i18n.T("tui.font-map"), c.FontMap, func() {
+				f.editText(i18n.T("tui.font-map"), i18n.T("tui.font-map-label"), &c.FontMap, config.ValidateFontMap)
+			}},
+			{i18n.T("tui.font-unimap"), c.FontUnimap, func() {
+				f.editText(i18n.T("tui.font-unimap"), i18n.T("tui.font-unimap-label"), &c.FontUnimap, config.ValidateFontUnimap)
+			}},
+			{i18n.T("tui.ttys"), ttys, func() {
+				s := ""
+				if c.TTYS != 0 {
+					s = strconv.Itoa(c.TTYS)
+				}
+				f.editText(i18n.T("tui.ttys"), i18n.T("tui.ttys-label"), &s, func(s string) error {
+					if strings.TrimSpace(s) == "" {
+						return nil
+					}
+					n, err := strconv.Atoi(strings.TrimSpace(s))
+					if err != nil {
+						return err
+					}
+					return config.ValidateTTYS(n)
+				})
+				c.TTYS, _ = strconv.Atoi(s)
+			}},
+			{i18n.T("tui.rc-vars"), strconv.Itoa(len(c.RCVars)), f.editRCVars},
+			{i18n.T("tui.done"), "", nil},
+		}
+	})
+}
+
+func (f *Frontend) editRCVars() {
+	c := f.config
+	f.form(i18n.T("tui.rc-vars"), func() []field {
+		names := []string{}
+		for name := range c.RCVars {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := []field{}
+		for _, name := range names {
+			name := name
+			fields = append(fields, field{name, c.RCVars[name], func() {
+				v := c.RCVars[name]
+				if s, ok := f.input("rc.conf", i18n.T("tui.rc-var-label", name), v, false, nil); ok {
+					if s == "" {
+						delete(c.RCVars, name)
+					} else {
+						c.RCVars[name] = s
+					}
+				}
+			}})
+		}
+		fields = append(fields, field{i18n.T("tui.rc-var-add"), "", func() {
+			s, ok := f.input("rc.conf", i18n.T("tui.rc-var-new"), "", false, func(s string) error {
+				parts := strings.SplitN(s, "=", 2)
+				if len(parts) != 2 {
+					return errors.New(i18n.T("tui.rc-var-format"))
+				}
+				return config.ValidateRCVar(parts[0])
+			})
+			if !ok {
+				return
+			}
+			parts := strings.SplitN(s, "=", 2)
+			if c.RCVars == nil {
+				c.RCVars = make(map[string]string)
+			}
+			c.RCVars[parts[0]] = parts[1]
+		}}, field{i18n.T("tui.done"), "", nil})
+		return fields
+	})
+}
+
+func (f *Frontend) bootloaderSummary() string {
+	if f.config.GRUB.InstallTo == "" {
+		return i18n.T("tui.not-installed")
+	}
+	return f.config.GRUB.InstallTo
+}
+
+// editBootloader picks the disk GRUB is installed to from those the
+// machine has.
+func (f *Frontend) editBootloader() {
+	disks := []string{}
+	items := []string{}
+	for _, d := range f.sysinfo.InstallableDisks() {
+		disks = append(disks, d.Path)
+		items = append(items, fmt.Sprintf("%-12s %10s", d.Path, sysinfo.HumanSize(d.SizeBytes)))
+	}
+	items = append(items, i18n.T("tui.grub-device"), i18n.T("tui.no-grub"))
+
+	selected := 0
+	for n, d := range disks {
+		if d == f.config.GRUB.InstallTo {
+			selected = n
+		}
+	}
+	n := f.menu(i18n.T("tui.grub-title"), items, selected)
+	switch {
+	case n < 0:
+		return
+	case n < len(disks):
+		f.config.GRUB.InstallTo = disks[n]
+	case n == len(disks):
+		f.editText(i18n.T("tui.grub-title"), i18n.T("tui.grub-device-label"), &f.config.GRUB.InstallTo, nil)
+	default:
+		f.config.GRUB.InstallTo = ""
+		return
+	}
+	f.config.GRUB.UseGraphical = f.yesNo(i18n.T("tui.section-bootloader"), i18n.T("tui.grub-graphical"))
+}
+
+// commonPackages are offered for ticking, anything else can be typed
+// in.
+var commonPackages = []string{"xorg", "xfce4", "NetworkManager", "firefox", "vim", "git"}
+
+func (f *Frontend) editPackages() {
+	c := f.config
+	checked := make([]bool, len(commonPackages))
+	extra := []string{}
+	for _, p := range c.Packages {
+		common := false
+		for n, cp := range commonPackages {
+			if p == cp {
+				checked[n] = true
+				common = true
+			}
+		}
+		if !common {
+			extra = append(extra, p)
+		}
+	}
+
+	checked, ok := f.checklist(i18n.T("tui.section-packages"), commonPackages, checked)
+	if !ok {
+		return
+	}
+	more, ok := f.input(i18n.T("tui.section-packages"), i18n.T("tui.packages-label"), strings.Join(extra, " "), false, nil)
+	if !ok {
+		more = strings.Join(extra, " ")
+	}
+
+	c.Packages = nil
+	for n, p := range commonPackages {
+		if checked[n] {
+			c.Packages = append(c.Packages, p)
+		}
+	}
+	c.Packages = append(c.Packages, strings.Fields(more)...)
+}
+
+func (f *Frontend) editNetwork() {
+	n := &f.config.Network
+	f.form(i18n.T("tui.section-network"), func() []field {
+		fields := []field{{i18n.T("tui.manager"), f.config.NetworkManager(), func() {
+			m := f.config.NetworkManager()
+			f.editChoice(i18n.T("tui.manager-title"), []string{"dhcpcd", "NetworkManager"}, &m)
+			n.Manager = m
+			if m == "NetworkManager" {
+				n.Interfaces, n.DNS, n.Wifi = nil, nil, nil
+			}
+		}}}
+		if f.config.NetworkManager() == "NetworkManager" {
+			return append(fields, field{i18n.T("tui.done"), "", nil})
+		}
+
+		for _, name := range f.interfaceNames() {
+			name := name
+			fields = append(fields, field{i18n.T("tui.interface", name), f.interfaceSummary(name), func() {
+				f.editInterface(name)
+			}})
+		}
+		fields = append(fields,
+			field{i18n.T("tui.other-interface"), "", func() {
+				name := ""
+				f.editText(i18n.T("tui.section-network"), i18n.T("tui.interface-label"), &name, func(s string) error {
+					return config.ValidateInterface(config.Interface{Name: strings.TrimSpace(s)})
+				})
+				if name != "" {
+					f.editInterface(name)
+				}
+			}},
+			field{i18n.T("tui.dns"), summarize(n.DNS), func() {
+				s := strings.Join(n.DNS, " ")
+				f.editText(i18n.T("tui.dns"), i18n.T("tui.dns-label"), &s, func(s string) error {
+					for _, ip := range strings.Fields(s) {
+						if err := config.ValidateIP(ip); err != nil {
+							return fmt.Errorf("%s: %v", ip, err)
+						}
+					}
+					return nil
+				})
+				n.DNS = strings.Fields(s)
+			}},
+			field{i18n.T("tui.wifi"), strconv.Itoa(len(n.Wifi)), f.editWifi},
+			field{i18n.T("tui.done"), "", nil},
+		)
+		return fields
+	})
+}
+
+// interfaceNames returns the interfaces the machine has followed by
+// those that are configured but weren't found.
+func (f *Frontend) interfaceNames() []string {
+	names := f.sysinfo.Interfaces()
+	for _, i := range f.config.Network.Interfaces {
+		found := false
+		for _, n := range names {
+			found = found || n == i.Name
+		}
+		if !found {
+			names = append(names, i.Name)
+		}
+	}
+	return names
+}
+
+func (f *Frontend) findInterface(name string) int {
+	for n, i := range f.config.Network.Interfaces {
+		if i.Name == name {
+			return n
+		}
+	}
+	return -1
+}
+
+func (f *Frontend) interfaceSummary(name string) string {
+	n := f.findInterface(name)
+	switch {
+	case n < 0 && len(f.config.Network.Interfaces) == 0:
+		return "DHCP"
+	case n < 0:
+		return i18n.T("tui.not-configured")
+	case len(f.config.Network.Interfaces[n].Addresses) == 0:
+		return "DHCP"
+	}
+	return strings.Join(f.config.Network.Interfaces[n].Addresses, " ")
+}
+
+func (f *Frontend) editInterface(name string) {
+	net := &f.config.Network
+	choice := f.menu(i18n.T("tui.interface", name), []string{"DHCP", i18n.T("tui.static"), i18n.T("tui.unconfigure")}, 0)
+	idx := f.findInterface(name)
+	switch choice {
+	case 0:
+		if idx < 0 {
+			net.Interfaces = append(net.Interfaces, config.Interface{Name: name})
+		} else {
+			net.Interfaces[idx].Addresses, net.Interfaces[idx].Gateway = nil, ""
+		}
+	case 1:
+		iface := config.Interface{Name: name}
+		if idx >= 0 {
+			iface = net.Interfaces[idx]
+		}
+		addr := strings.Join(iface.Addresses, " ")
+		f.editText(i18n.T("tui.interface", name), i18n.T("tui.addresses-label"), &addr, func(s string) error {
+			return config.ValidateInterface(config.Interface{Name: name, Addresses: strings.Fields(s)})
+		})
+		iface.Addresses = strings.Fields(addr)
+		f.editText(i18n.T("tui.interface", name), i18n.T("tui.gateway-label"), &iface.Gateway, func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return nil
+			}
+			return config.ValidateInterface(config.Interface{Name: name, Gateway: strings.TrimSpace(s)})
+		})
+		if idx < 0 {
+			net.Interfaces = append(net.Interfaces, iface)
+		} else {
+			net.Interfaces[idx] = iface
+		}
+	case 2:
+		if idx >= 0 {
+			net.Interfaces = append(net.Interfaces[:idx], net.Interfaces[idx+1:]...)
+		}
+	}
+}
+
+func (f *Frontend) editWifi() {
+	net := &f.config.Network
+	f.form(i18n.T("tui.wifi"), func() []field {
+		fields := []field{}
+		for n, w := range net.Wifi {
+			n := n
+			security := i18n.T("tui.wifi-open")
+			if w.PSK != "" {
+				security = i18n.T("tui.wifi-protected")
+			}
+			fields = append(fields, field{w.SSID, security, func() {
+				if f.yesNo(i18n.T("tui.wifi-title"), i18n.T("tui.wifi-forget", net.Wifi[n].SSID)) {
+					net.Wifi = append(net.Wifi[:n], net.Wifi[n+1:]...)
+				}
+			}})
+		}
+		return append(fields, field{i18n.T("tui.wifi-add"), "", func() {
+			w := config.Wifi{}
+			f.editText(i18n.T("tui.wifi-title"), i18n.T("tui.ssid-label"), &w.SSID, nil)
+			if w.SSID == "" {
+				return
+			}
+			psk, ok := f.input(i18n.T("tui.wifi-title"), i18n.T("tui.psk-label"), "", true, func(s string) error {
+				return config.ValidateWifi(config.Wifi{SSID: w.SSID, PSK: s})
+			})
+			if !ok {
+				return
+			}
+			w.PSK = psk
+			net.Wifi = append(net.Wifi, w)
+		}}, field{i18n.T("tui.done"), "", nil})
+	})
+}
+
+func (f *Frontend) servicesSummary() string {
+	names := []string{}
+	for _, s := range f.config.Services {
+		if s.Disable {
+			names = append(names, "-"+s.Name)
+		} else {
+			names = append(names, s.Name)
+		}
+	}
+	if len(names) == 0 {
+		return i18n.T("tui.defaults")
+	}
+	return summarize(names)
+}
+
+func (f *Frontend) editServices() {
+	c := f.config
+	enable, disable := []string{}, []string{}
+	for _, s := range c.Services {
+		if s.Disable {
+			disable = append(disable, s.Name)
+		} else {
+			enable = append(enable, s.Name)
+		}
+	}
+	check := func(s string) error {
+		for _, name := range strings.Fields(s) {
+			if err := config.ValidateService(config.Service{Name: name}); err != nil {
+				return fmt.Errorf("%s: %v", name, err)
+			}
+		}
+		return nil
+	}
+
+	e, ok := f.input(i18n.T("tui.section-services"), i18n.T("tui.services-enable"), strings.Join(enable, " "), false, check)
+	if !ok {
+		return
+	}
+	d, ok := f.input(i18n.T("tui.section-services"), i18n.T("tui.services-disable"), strings.Join(disable, " "), false, check)
+	if !ok {
+		return
+	}
+	c.Services = nil
+	for _, name := range strings.Fields(e) {
+		c.Services = append(c.Services, config.Service{Name: name})
+	}
+	for _, name := range strings.Fields(d) {
+		c.Services = append(c.Services, config.Service{Name: name, Disable: true})
+	}
+}
+
+func (f *Frontend) rootSummary() string {
+	c := f.config
+	switch {
+	case c.LockRoot:
+		return i18n.T("tui.locked")
+	case c.RootPassword != "" || c.RootPasswordHash != "":
+		return i18n.T("tui.password-set")
+	}
+	return i18n.T("tui.no-password")
+}
+
+func (f *Frontend) editRoot() {
+	c := f.config
+	f.form(i18n.T("tui.section-root"), func() []field {
+		password := i18n.T("tui.not-set")
+		if c.RootPassword != "" || c.RootPasswordHash != "" {
+			password = i18n.T("tui.set")
+		}
+		return []field{
+			{i18n.T("tui.password"), password, func() {
+				p, ok := f.newPassword(i18n.T("tui.section-root"), "root")
+				if !ok {
+					return
+				}
+				if p.lock && !c.HasWheelUser() {
+					f.message(i18n.T("tui.section-root"), i18n.T("tui.root-lock-wheel"))
+					return
+				}
+				c.RootPassword, c.RootPasswordHash, c.LockRoot = p.clear, p.hash, p.lock
+			}},
+			{i18n.T("tui.lock-root"), yesNo(c.LockRoot), func() {
+				if !c.LockRoot && !c.HasWheelUser() {
+					f.message(i18n.T("tui.section-root"), i18n.T("tui.root-lock-wheel"))
+					return
+				}
+				c.LockRoot = !c.LockRoot
+			}},
+			{i18n.T("tui.shell"), c.RootShell, func() {
+				f.editText(i18n.T("tui.section-root"), i18n.T("tui.shell-label"), &c.RootShell, config.ValidateShell)
+			}},
+			{i18n.T("tui.done"), "", nil},
+		}
+	})
+}
+
+// password is what was chosen for an account: a password, a hash
+// pasted in, or neither and the account locked.
+type password struct {
+	clear string
+	hash  string
+	lock  bool
+}
+
+// newPassword asks for a password twice, pointing out weak ones,
+// names being those of the account.  A hash may be pasted instead,
+// or ! given to lock the account.  ok is false if the user backed
+// out.
+func (f *Frontend) newPassword(title string, names ...string) (password, bool) {
+	for {
+		p, ok := f.input(title, i18n.T("tui.password-label"), "", true, nil)
+		if !ok {
+			return password{}, false
+		}
+		switch {
+		case p == "!":
+			return password{lock: true}, true
+		case strings.HasPrefix(p, "$"):
+			if err := config.ValidatePasswordHash(p); err != nil {
+				f.message(title, err.Error())
+				continue
+			}
+			return password{hash: p}, true
+		}
+
+		if weak := shadow.Weaknesses(p, names...); len(weak) > 0 {
+			if !f.yesNo(title, i18n.T("tui.password-weak", strings.Join(weak, ", "))) {
+				continue
+			}
+		}
+		again, ok := f.input(title, i18n.T("tui.password-again"), "", true, nil)
+		if !ok {
+			return password{}, false
+		}
+		if p == again {
+			return password{clear: p}, true
+		}
+		f.message(title, i18n.T("tui.password-mismatch"))
+	}
+}
+
+func (f *Frontend) usersSummary() string {
+	names := []string{}
+	for _, u := range f.config.Users {
+		names = append(names, u.Username)
+	}
+	if len(names) == 0 {
+		return i18n.T("tui.none")
+	}
+	return summarize(names)
+}
+
+func (f *Frontend) editUsers() {
+	c := f.config
+	f.form(i18n.T("tui.section-users"), func() []field {
+		fields := []field{}
+		for n, u := range c.Users {
+			n := n
+			fields = append(fields, field{u.Username, u.GECOS, func() {
+				f.editUser(n)
+			}})
+		}
+		return append(fields, field{i18n.T("tui.user-add"), "", func() {
+			u := config.User{Groups: []string{"wheel"}}
+			f.editText(i18n.T("tui.user-new"), i18n.T("tui.username-label"), &u.Username, f.validateUsername(len(c.Users)))
+			if u.Username == "" {
+				return
+			}
+			c.Users = append(c.Users, u)
+			f.editUser(len(c.Users) - 1)
+		}}, field{i18n.T("tui.done"), "", nil})
+	})
+}
+
+// editUser edits the user at index n, which may be deleted.
+func (f *Frontend) editUser(n int) {
+	c := f.config
+	deleted := false
+	f.form(i18n.T("tui.user"), func() []field {
+		if deleted {
+			return nil
+		}
+		u := &c.Users[n]
+		password := i18n.T("tui.not-set")
+		switch {
+		case u.Lock:
+			password = i18n.T("tui.locked")
+		case u.Password != "" || u.PasswordHash != "":
+			password = i18n.T("tui.set")
+		}
+		return []field{
+			{i18n.T("tui.username"), u.Username, func() {
+				f.editText(i18n.T("tui.user"), i18n.T("tui.username-label"), &u.Username, f.validateUsername(n))
+			}},
+			{i18n.T("tui.gecos"), u.GECOS, func() {
+				f.editText(i18n.T("tui.user"), i18n.T("tui.gecos-label"), &u.GECOS, nil)
+			}},
+			{i18n.T("tui.password"), password, func() {
+				if p, ok := f.newPassword(i18n.T("tui.user-named", u.Username), u.Username, u.GECOS); ok {
+					u.Password, u.PasswordHash, u.Lock = p.clear, p.hash, p.lock
+				}
+			}},
+			{i18n.T("tui.groups"), strings.Join(u.Groups, ","), func() {
+				groups := strings.Join(u.Groups, ",")
+				f.editText(i18n.T("tui.user"), i18n.T("tui.groups-label"), &groups, nil)
+				u.Groups = nil
+				for _, g := range strings.Split(groups, ",") {
+					if g = strings.TrimSpace(g); g != "" {
+						u.Groups = append(u.Groups, g)
+					}
+				}
+			}},
+			{i18n.T("tui.shell"), u.Shell, func() {
+				f.editText(i18n.T("tui.user"), i18n.T("tui.shell-label"), &u.Shell, config.ValidateShell)
+			}},
+			{i18n.T("tui.user-delete"), "", func() {
+				if f.yesNo(i18n.T("tui.user"), i18n.T("tui.user-delete-question", u.Username)) {
+					c.Users = append(c.Users[:n], c.Users[n+1:]...)
+					deleted = true
+				}
+			}},
+			{i18n.T("tui.done"), "", nil},
+		}
+	})
+}
+
+// validateUsername checks a name for the user at index n, which
+// no other user may have.
+func (f *Frontend) validateUsername(n int) func(string) error {
+	return func(s string) error {
+		if err := config.ValidateUsername(s); err != nil {
+			return err
+		}
+		for m, other := range f.config.Users {
+			if m != n && other.Username == s {
+				return config.ErrDuplicateUser
+			}
+		}
+		return nil
+	}
+}
+
+func (f *Frontend) editPrivilege() {
+	p := &f.config.Privilege
+	f.form(i18n.T("tui.section-privileges"), func() []field {
+		return []field{
+			{i18n.T("tui.tool"), f.config.PrivilegeTool(), func() {
+				t := f.config.PrivilegeTool()
+				f.editChoice(i18n.T("tui.section-privileges"), []string{"sudo", "doas"}, &t)
+				p.Tool = t
+			}},
+			{i18n.T("tui.wheel-password"), yesNo(!p.NoPassword), func() {
+				p.NoPassword = !p.NoPassword
+			}},
+			{i18n.T("tui.done"), "", nil},
+		}
+	})
+}
@@ -0,0 +1,201 @@
+// Package tui is a full screen frontend for terminals.  Every part of
+// the configuration has its own menu which can be revisited as often
+// as needed before the installation starts, and the installation is
+// shown with a progress bar above its log.
+//
+// The frontend draws on a tcell.Screen.  NewWithScreen accepts any
+// screen, which lets a tcell.SimulationScreen stand in for a
+// terminal so that the frontend can be driven by injected key
+// presses and its contents inspected.
+package tui
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gdamore/tcell"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// logFile receives the log while the screen is in use, as anything
+// written to the terminal would end up on top of the menus.
+var logFile = filepath.Join(os.TempDir(), "vInstaller.log")
+
+// Frontend presents the installer as full screen menus.
+type Frontend struct {
+	screen    tcell.Screen
+	newScreen func() (tcell.Screen, error)
+	started   bool
+	logOutput io.WriteCloser
+
+	// cancel cancels the installation, if main said how.
+	cancel func()
+
+	sysinfo *sysinfo.System
+	config  *config.Config
+}
+
+// New returns a frontend that draws on the terminal.
+func New() (frontend.InstallerFrontend, error) {
+	return &Frontend{newScreen: tcell.NewScreen}, nil
+}
+
+// NewWithScreen returns a frontend that draws on s and describes the
+// machine with sys, which is discovered if nil.
+func NewWithScreen(s tcell.Screen, sys *sysinfo.System) *Frontend {
+	return &Frontend{
+		newScreen: func() (tcell.Screen, error) { return s, nil },
+		sysinfo:   sys,
+	}
+}
+
+func init() {
+	frontend.Register(frontend.Registration{
+		Name:         "tui",
+		Description:  "Full screen menus for the local console",
+		Capabilities: frontend.Interactive | frontend.NeedsTTY | frontend.FullScreen,
+		Factory:      New,
+	})
+}
+
+// SetCancel sets how Ctrl-C cancels the installation.
+func (f *Frontend) SetCancel(cancel func()) {
+	f.cancel = cancel
+}
+
+// start takes over the terminal.
+func (f *Frontend) start() error {
+	if f.started {
+		return nil
+	}
+	s, err := f.newScreen()
+	if err != nil {
+		return err
+	}
+	if err := s.Init(); err != nil {
+		return err
+	}
+	f.screen = s
+	f.started = true
+
+	if out, err := os.OpenFile(logFile, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600); err == nil {
+		f.logOutput = out
+		log.SetOutput(out)
+	}
+	return nil
+}
+
+// stop gives the terminal back.
+func (f *Frontend) stop() {
+	if !f.started {
+		return
+	}
+	f.screen.Fini()
+	f.started = false
+	if f.logOutput != nil {
+		log.SetOutput(os.Stderr)
+		f.logOutput.Close()
+		f.logOutput = nil
+		log.Printf("The log of the installer is in %s", logFile)
+	}
+}
+
+// GetInstallerConfig shows the main menu until the user is happy
+// with the configuration.
+func (f *Frontend) GetInstallerConfig() (*config.Config, error) {
+	if err := f.start(); err != nil {
+		log.Printf("Could not start the terminal UI: %v", err)
+		return nil, frontend.ErrConfigUnobtainable
+	}
+	if f.sysinfo == nil {
+		top, _, w := f.frame(i18n.T("tui.title"), "")
+		f.drawText(2, top, w-4, styleNormal, i18n.T("tui.inspecting"))
+		f.screen.Show()
+		f.sysinfo = sysinfo.DiscoverHardware()
+	}
+	if f.config == nil {
+		f.config = new(config.Config)
+	}
+
+	cursor := 0
+	for {
+		sections := f.sections()
+		items := []string{}
+		for _, s := range sections {
+			items = append(items, formatField(s.label, s.value))
+		}
+		items = append(items, "", i18n.T("tui.review-install"))
+
+		n := f.menu(i18n.T("tui.title"), items, cursor)
+		switch {
+		case n < 0:
+			if f.yesNo(i18n.T("tui.quit"), i18n.T("tui.quit-question")) {
+				f.stop()
+				return nil, frontend.ErrInstallationAborted
+			}
+		case n < len(sections):
+			cursor = n
+			sections[n].edit()
+		case n == len(items)-1:
+			cursor = n
+			if f.review() {
+				return f.config, nil
+			}
+		}
+	}
+}
+
+// review checks the configuration and shows it, returning true if
+// the user wants to go ahead with it.
+func (f *Frontend) review() bool {
+	if err := f.config.Validate(); err != nil {
+		text := i18n.T("tui.problems") + "\n\n"
+		if errs, ok := err.(config.ValidationErrors); ok {
+			for _, e := range errs {
+				text += "  " + e.Error() + "\n"
+			}
+		} else {
+			text += "  " + err.Error() + "\n"
+		}
+		f.message(i18n.T("tui.review"), text)
+		return false
+	}
+	for {
+		ev := f.pager(i18n.T("tui.review"), f.config.String(), i18n.T("tui.help-review"))
+		if ev == nil || ev.Key() == tcell.KeyEscape {
+			return false
+		}
+		if ev.Key() == tcell.KeyEnter {
+			return true
+		}
+	}
+}
+
+// ConfirmInstallation asks for the go ahead before anything on disk
+// is touched.
+func (f *Frontend) ConfirmInstallation() error {
+	if err := f.start(); err != nil {
+		return err
+	}
+	if f.yesNo(i18n.T("tui.confirm"), i18n.T("tui.confirm-question")) {
+		return nil
+	}
+	f.stop()
+	return frontend.ErrInstallationAborted
+}
+
+// summarize shortens a list for a menu.
+func summarize(items []string) string {
+	s := []rune(strings.Join(items, " "))
+	if len(s) > 40 {
+		return string(s[:37]) + "..."
+	}
+	return string(s)
+}
@@ -0,0 +1,266 @@
+package tui
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	// The tests look for the English text.
+	flag.Set("lang", "en")
+	os.Exit(m.Run())
+}
+
+// testScreen is a simulated terminal.  The frontend draws on it from
+// another goroutine, and the simulation hands out the cells it draws
+// in, so drawing and looking are taken in turns.
+type testScreen struct {
+	tcell.SimulationScreen
+	mu sync.Mutex
+}
+
+func newTestScreen() *testScreen {
+	return &testScreen{SimulationScreen: tcell.NewSimulationScreen("UTF-8")}
+}
+
+func (s *testScreen) Init() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.SimulationScreen.Init()
+}
+
+func (s *testScreen) Show() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.SimulationScreen.Show()
+}
+
+func (s *testScreen) Sync() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.SimulationScreen.Sync()
+}
+
+func (s *testScreen) Fini() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.SimulationScreen.Fini()
+}
+
+// text returns what is on the screen, a line per row.
+func (s *testScreen) text() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cells, w, h := s.GetContents()
+	var b strings.Builder
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if r := cells[y*w+x].Runes; len(r) > 0 {
+				b.WriteRune(r[0])
+			} else {
+				b.WriteRune(' ')
+			}
+		}
+		b.WriteRune('\n')
+	}
+	return b.String()
+}
+
+// waitFor waits for text to show up on the screen.
+func (s *testScreen) waitFor(t *testing.T, text string) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(s.text(), text) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%q never showed up on the screen:\n%s", text, s.text())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// press sends keys to the frontend.
+func (s *testScreen) press(keys ...tcell.Key) {
+	for _, k := range keys {
+		s.PostEventWait(tcell.NewEventKey(k, 0, tcell.ModNone))
+	}
+}
+
+// typeText sends text to the frontend as if it was typed.
+func (s *testScreen) typeText(text string) {
+	for _, r := range text {
+		s.PostEventWait(tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+	}
+}
+
+func TestSections(t *testing.T) {
+	s := newTestScreen()
+	f := NewWithScreen(s, &sysinfo.System{})
+	errs := make(chan error, 1)
+	go func() {
+		_, err := f.GetInstallerConfig()
+		errs <- err
+	}()
+
+	s.waitFor(t, "Void Linux Installer")
+	s.waitFor(t, "Review and install")
+
+	// The hostname is the second section.
+	s.press(tcell.KeyDown, tcell.KeyEnter)
+	s.waitFor(t, "Name of the system")
+	s.typeText("void")
+	s.press(tcell.KeyEnter)
+	s.waitFor(t, "Domain of the system")
+	s.press(tcell.KeyEnter)
+	s.waitFor(t, padRight("Hostname", 20)+" void")
+
+	// Going back to a section starts from what was entered, and
+	// the menu comes back on the same section.
+	s.press(tcell.KeyEnter)
+	s.waitFor(t, " void ")
+	s.press(tcell.KeyCtrlU)
+	s.typeText("box.example.org")
+	s.press(tcell.KeyEnter)
+	s.waitFor(t, padRight("Hostname", 20)+" box.example.org")
+	if f.config.Domain != "" {
+		t.Errorf("a fully qualified hostname left the domain %q", f.config.Domain)
+	}
+
+	// Typing narrows the menu down.
+	s.typeText("zone")
+	s.waitFor(t, "Search: zone")
+	if strings.Contains(s.text(), "Hostname") {
+		t.Errorf("the search for zone still shows the hostname:\n%s", s.text())
+	}
+	s.press(tcell.KeyEscape)
+	s.waitFor(t, "Hostname")
+
+	s.press(tcell.KeyEscape)
+	s.waitFor(t, "Leave the installer without installing anything?")
+	s.typeText("y")
+	select {
+	case err := <-errs:
+		if err != frontend.ErrInstallationAborted {
+			t.Errorf("quitting returned %v, want %v", err, frontend.ErrInstallationAborted)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("quitting didn't return")
+	}
+}
+
+func TestProgress(t *testing.T) {
+	s := newTestScreen()
+	f := NewWithScreen(s, &sysinfo.System{})
+	cancelled := make(chan struct{}, 1)
+	f.SetCancel(func() { cancelled <- struct{}{} })
+
+	output := make(chan i18n.Message)
+	errs := make(chan error)
+	done := make(chan bool)
+	finished := make(chan struct{})
+	go func() {
+		f.ShowInstallationProgress(output, errs, done)
+		close(finished)
+	}()
+
+	s.waitFor(t, "Installing Void Linux")
+	output <- i18n.M("installer.step", 1, 2, "base-system")
+	s.waitFor(t, "Step 1 of 2: base-system")
+	output <- i18n.Text("installing the base system")
+	s.waitFor(t, "installing the base system")
+
+	s.press(tcell.KeyCtrlC)
+	s.waitFor(t, "Cancelling the installation...")
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Ctrl-C didn't cancel the installation")
+	}
+
+	errs <- errors.New("installation cancelled")
+	close(done)
+	close(output)
+	close(errs)
+	s.waitFor(t, "The installation failed, see the log below")
+	s.waitFor(t, "installation cancelled")
+
+	s.press(tcell.KeyEnter)
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Enter didn't leave the finished installation")
+	}
+}
+
+// Without a screen the installation is printed, and everything is
+// still read until the installer is done.
+func TestProgressWithoutScreen(t *testing.T) {
+	f := &Frontend{newScreen: func() (tcell.Screen, error) {
+		return nil, errors.New("no terminal")
+	}}
+	output := make(chan i18n.Message)
+	errs := make(chan error)
+	done := make(chan bool)
+	finished := make(chan struct{})
+	go func() {
+		f.ShowInstallationProgress(output, errs, done)
+		close(finished)
+	}()
+
+	output <- i18n.Text("a line")
+	errs <- errors.New("an error")
+	close(done)
+	output <- i18n.Text("a line after done")
+	close(output)
+	close(errs)
+	select {
+	case <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the printed installation never finished")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	for _, c := range []struct {
+		items []string
+		want  string
+	}{
+		{[]string{"wheel", "audio"}, "wheel audio"},
+		{[]string{strings.Repeat("a", 40)}, strings.Repeat("a", 40)},
+		{[]string{strings.Repeat("a", 41)}, strings.Repeat("a", 37) + "..."},
+		{[]string{strings.Repeat("ü", 40)}, strings.Repeat("ü", 40)},
+		{[]string{strings.Repeat("日本", 30)}, strings.Repeat("日本", 18) + "日..."},
+	} {
+		if got := summarize(c.items); got != c.want {
+			t.Errorf("summarize(%q) = %q, want %q", c.items, got, c.want)
+		}
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	f := &Frontend{config: &config.Config{Users: []config.User{{Username: "alice"}, {Username: "bob"}}}}
+	if err := f.validateUsername(1)("alice"); err != config.ErrDuplicateUser {
+		t.Errorf("renaming bob to alice: %v, want %v", err, config.ErrDuplicateUser)
+	}
+	if err := f.validateUsername(2)("bob"); err != config.ErrDuplicateUser {
+		t.Errorf("adding another bob: %v, want %v", err, config.ErrDuplicateUser)
+	}
+	if err := f.validateUsername(0)("alice"); err != nil {
+		t.Errorf("keeping alice's name: %v", err)
+	}
+	if err := f.validateUsername(2)("Carol"); err != config.ErrBadUsername {
+		t.Errorf("adding Carol: %v, want %v", err, config.ErrBadUsername)
+	}
+}
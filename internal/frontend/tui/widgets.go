@@ -0,0 +1,449 @@
+package tui
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+var (
+	styleNormal   = tcell.StyleDefault
+	styleTitle    = tcell.StyleDefault.Reverse(true).Bold(true)
+	styleSelected = tcell.StyleDefault.Reverse(true)
+	styleHelp     = tcell.StyleDefault.Dim(true)
+	styleError    = tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)
+)
+
+// drawText draws s at x, y, cut off after w columns, and returns the
+// column after the last one drawn.
+func (f *Frontend) drawText(x, y, w int, style tcell.Style, s string) int {
+	for _, r := range s {
+		if w <= 0 {
+			break
+		}
+		f.screen.SetContent(x, y, r, nil, style)
+		x++
+		w--
+	}
+	return x
+}
+
+// fillLine paints the whole of row y in style.
+func (f *Frontend) fillLine(y int, style tcell.Style) {
+	w, _ := f.screen.Size()
+	for x := 0; x < w; x++ {
+		f.screen.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+// frame clears the screen and draws the title and help lines.  It
+// returns the first and last rows left for the content, and the
+// width of the screen.
+func (f *Frontend) frame(title, help string) (top, bottom, width int) {
+	f.screen.Clear()
+	f.screen.HideCursor()
+	w, h := f.screen.Size()
+	f.fillLine(0, styleTitle)
+	f.drawText(1, 0, w-2, styleTitle, title)
+	f.drawText(1, h-1, w-2, styleHelp, help)
+	return 2, h - 3, w
+}
+
+// nextKey waits for a key press, redrawing with draw whenever the
+// screen changes size.  It returns nil once the screen has been shut
+// down.
+func (f *Frontend) nextKey(draw func()) *tcell.EventKey {
+	for {
+		draw()
+		f.screen.Show()
+		switch ev := f.screen.PollEvent().(type) {
+		case nil:
+			return nil
+		case *tcell.EventResize:
+			f.screen.Sync()
+		case *tcell.EventKey:
+			return ev
+		}
+	}
+}
+
+// scroll moves a cursor within n items by a key press, returning the
+// new position and whether the key was one that moves it.
+func scroll(key tcell.Key, cursor, n, page int) (int, bool) {
+	switch key {
+	case tcell.KeyUp:
+		cursor--
+	case tcell.KeyDown:
+		cursor++
+	case tcell.KeyPgUp:
+		cursor -= page
+	case tcell.KeyPgDn:
+		cursor += page
+	case tcell.KeyHome:
+		cursor = 0
+	case tcell.KeyEnd:
+		cursor = n - 1
+	default:
+		return cursor, false
+	}
+	if cursor >= n {
+		cursor = n - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor, true
+}
+
+// offsetFor returns the first item to show so that the cursor stays
+// within a window of rows items.
+func offsetFor(cursor, offset, rows int) int {
+	if cursor < offset {
+		offset = cursor
+	}
+	if rows > 0 && cursor >= offset+rows {
+		offset = cursor - rows + 1
+	}
+	return offset
+}
+
+// menu lets the user pick one of items, starting at selected.
+// Typing narrows the list down to the items containing what was
+// typed.  It returns the index of the chosen item, or -1 if the user
+// backed out.
+func (f *Frontend) menu(title string, items []string, selected int) int {
+	filter := ""
+	cursor, offset := selected, 0
+	visible := []int{}
+
+	draw := func() {
+		visible = visible[:0]
+		for n, item := range items {
+			if strings.Contains(strings.ToLower(item), strings.ToLower(filter)) {
+				visible = append(visible, n)
+			}
+		}
+		if cursor >= len(visible) {
+			cursor = len(visible) - 1
+		}
+		if cursor < 0 {
+			cursor = 0
+		}
+
+		top, bottom, w := f.frame(title, i18n.T("tui.help-menu"))
+		if filter != "" {
+			f.drawText(2, top, w-4, styleNormal, i18n.T("tui.search", filter))
+		}
+		top += 2
+		rows := bottom - top + 1
+		offset = offsetFor(cursor, offset, rows)
+		if len(visible) == 0 {
+			f.drawText(2, top, w-4, styleHelp, i18n.T("tui.no-match"))
+		}
+		for row := 0; row < rows && offset+row < len(visible); row++ {
+			style := styleNormal
+			if offset+row == cursor {
+				style = styleSelected
+			}
+			f.drawText(2, top+row, w-4, style, items[visible[offset+row]])
+		}
+	}
+
+	for {
+		ev := f.nextKey(draw)
+		if ev == nil {
+			return -1
+		}
+		_, h := f.screen.Size()
+		if c, ok := scroll(ev.Key(), cursor, len(visible), h-6); ok {
+			cursor = c
+			continue
+		}
+		switch ev.Key() {
+		case tcell.KeyEnter:
+			if len(visible) > 0 {
+				return visible[cursor]
+			}
+		case tcell.KeyEscape:
+			if filter == "" {
+				return -1
+			}
+			filter = ""
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if filter != "" {
+				r := []rune(filter)
+				filter = string(r[:len(r)-1])
+			}
+		case tcell.KeyRune:
+			filter += string(ev.Rune())
+			cursor, offset = 0, 0
+		}
+	}
+}
+
+// input asks for a line of text, starting from value.  Masked input
+// is shown as asterisks.  check, if not nil, is run when Enter is
+// pressed, and the error it returns is shown until the text is
+// changed.  ok is false if the user backed out.
+func (f *Frontend) input(title, label, value string, masked bool, check func(string) error) (string, bool) {
+	text := []rune(value)
+	pos := len(text)
+	var err error
+
+	draw := func() {
+		top, _, w := f.frame(title, i18n.T("tui.help-input"))
+		f.drawText(2, top, w-4, styleNormal, label)
+		shown := string(text)
+		if masked {
+			shown = strings.Repeat("*", len(text))
+		}
+		// Keep the cursor on screen for text longer than it.
+		start := 0
+		if pos > w-6 {
+			start = pos - (w - 6)
+		}
+		f.drawText(2, top+2, w-4, styleSelected, " "+string([]rune(shown)[start:])+" ")
+		f.screen.ShowCursor(3+pos-start, top+2)
+		if err != nil {
+			f.drawText(2, top+4, w-4, styleError, err.Error())
+		}
+	}
+
+	for {
+		ev := f.nextKey(draw)
+		if ev == nil {
+			return value, false
+		}
+		switch ev.Key() {
+		case tcell.KeyEnter:
+			s := string(text)
+			if check != nil {
+				if err = check(s); err != nil {
+					continue
+				}
+			}
+			f.screen.HideCursor()
+			return s, true
+		case tcell.KeyEscape:
+			f.screen.HideCursor()
+			return value, false
+		case tcell.KeyLeft:
+			if pos > 0 {
+				pos--
+			}
+		case tcell.KeyRight:
+			if pos < len(text) {
+				pos++
+			}
+		case tcell.KeyHome, tcell.KeyCtrlA:
+			pos = 0
+		case tcell.KeyEnd, tcell.KeyCtrlE:
+			pos = len(text)
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			if pos > 0 {
+				text = append(text[:pos-1], text[pos:]...)
+				pos--
+				err = nil
+			}
+		case tcell.KeyDelete:
+			if pos < len(text) {
+				text = append(text[:pos], text[pos+1:]...)
+				err = nil
+			}
+		case tcell.KeyCtrlU:
+			text, pos, err = nil, 0, nil
+		case tcell.KeyRune:
+			text = append(text[:pos], append([]rune{ev.Rune()}, text[pos:]...)...)
+			pos++
+			err = nil
+		}
+	}
+}
+
+// wrap breaks text into lines no wider than w.
+func wrap(text string, w int) []string {
+	lines := []string{}
+	for _, l := range strings.Split(text, "\n") {
+		r := []rune(l)
+		for w > 0 && len(r) > w {
+			lines = append(lines, string(r[:w]))
+			r = r[w:]
+		}
+		lines = append(lines, string(r))
+	}
+	return lines
+}
+
+// pager shows text that may be longer than the screen.  The arrow
+// and page keys scroll, any other key ends it and is returned.  It
+// returns nil once the screen has been shut down.
+func (f *Frontend) pager(title, text, help string) *tcell.EventKey {
+	offset := 0
+	rows := 0
+	var lines []string
+
+	draw := func() {
+		top, bottom, w := f.frame(title, help)
+		lines = wrap(text, w-4)
+		rows = bottom - top + 1
+		if offset > len(lines)-rows {
+			offset = len(lines) - rows
+		}
+		if offset < 0 {
+			offset = 0
+		}
+		for row := 0; row < rows && offset+row < len(lines); row++ {
+			f.drawText(2, top+row, w-4, styleNormal, lines[offset+row])
+		}
+	}
+
+	for {
+		ev := f.nextKey(draw)
+		if ev == nil {
+			return nil
+		}
+		// The pager scrolls the view rather than a cursor, so
+		// the cursor is the first line shown.
+		last := len(lines) - rows
+		if last < 0 {
+			last = 0
+		}
+		if o, ok := scroll(ev.Key(), offset, last+1, rows); ok {
+			offset = o
+			continue
+		}
+		return ev
+	}
+}
+
+// message shows text until the user presses a key.
+func (f *Frontend) message(title, text string) {
+	f.pager(title, text, i18n.T("tui.help-message"))
+}
+
+// yesNo asks a question and returns true if it was answered with y,
+// or the first letter of yes in the language the user reads.
+func (f *Frontend) yesNo(title, text string) bool {
+	yes := initial(i18n.T("tui.yes"))
+	no := initial(i18n.T("tui.no"))
+	for {
+		ev := f.pager(title, text, i18n.T("tui.help-yes-no"))
+		if ev == nil || ev.Key() == tcell.KeyEscape {
+			return false
+		}
+		switch r := unicode.ToLower(ev.Rune()); {
+		case r == 'y' || r == yes:
+			return true
+		case r == 'n' || r == no:
+			return false
+		}
+	}
+}
+
+// initial returns the first letter of s in lower case.
+func initial(s string) rune {
+	for _, r := range s {
+		return unicode.ToLower(r)
+	}
+	return 0
+}
+
+// checklist lets the user tick any number of items with space.  It
+// returns the new state, and ok is false if the user backed out.
+func (f *Frontend) checklist(title string, items []string, checked []bool) ([]bool, bool) {
+	state := append([]bool{}, checked...)
+	for len(state) < len(items) {
+		state = append(state, false)
+	}
+	cursor, offset := 0, 0
+	rows := 0
+
+	draw := func() {
+		top, bottom, w := f.frame(title, i18n.T("tui.help-checklist"))
+		rows = bottom - top + 1
+		offset = offsetFor(cursor, offset, rows)
+		for row := 0; row < rows && offset+row < len(items); row++ {
+			n := offset + row
+			box := "[ ] "
+			if state[n] {
+				box = "[x] "
+			}
+			style := styleNormal
+			if n == cursor {
+				style = styleSelected
+			}
+			f.drawText(2, top+row, w-4, style, box+items[n])
+		}
+	}
+
+	for {
+		ev := f.nextKey(draw)
+		if ev == nil {
+			return checked, false
+		}
+		if c, ok := scroll(ev.Key(), cursor, len(items), rows); ok {
+			cursor = c
+			continue
+		}
+		switch {
+		case ev.Key() == tcell.KeyEnter:
+			return state, true
+		case ev.Key() == tcell.KeyEscape:
+			return checked, false
+		case ev.Key() == tcell.KeyRune && ev.Rune() == ' ' && len(items) > 0:
+			state[cursor] = !state[cursor]
+		}
+	}
+}
+
+// field is an entry in a form, showing the current value of a
+// setting and editing it when chosen.
+type field struct {
+	label string
+	value string
+	edit  func()
+}
+
+// form shows fields until the user backs out, chooses a field that
+// can't be edited, or there are no fields left.  fields is called
+// again after every edit, as values and even which fields there are
+// can change.
+func (f *Frontend) form(title string, fields func() []field) {
+	cursor := 0
+	for {
+		fs := fields()
+		if len(fs) == 0 {
+			return
+		}
+		items := make([]string, len(fs))
+		for n, fl := range fs {
+			items[n] = formatField(fl.label, fl.value)
+		}
+		n := f.menu(title, items, cursor)
+		if n < 0 {
+			return
+		}
+		cursor = n
+		if fs[n].edit == nil {
+			return
+		}
+		fs[n].edit()
+	}
+}
+
+func formatField(label, value string) string {
+	if value == "" {
+		return label
+	}
+	return padRight(label, 20) + " " + value
+}
+
+func padRight(s string, n int) string {
+	if l := len([]rune(s)); l < n {
+		return s + strings.Repeat(" ", n-l)
+	}
+	return s
+}
@@ -0,0 +1,252 @@
+package web
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+)
+
+// sessionCookie carries the session of a browser.
+const sessionCookie = "vinstaller_session"
+
+// Handler returns the handler that serves the UI and the API.
+func (f *Frontend) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", f.handlePage)
+	mux.HandleFunc("/api/login", f.handleLogin)
+	mux.Handle("/api/state", f.authorized(f.handleState))
+	mux.Handle("/api/system", f.authorized(f.handleSystem))
+	mux.Handle("/api/config", f.authorized(f.handleConfig))
+	mux.Handle("/api/confirm", f.authorized(f.handleConfirm))
+	mux.Handle("/api/events", f.authorized(f.handleEvents))
+	return mux
+}
+
+// writeJSON sends v as the response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// apiError is the body of every error response.
+type apiError struct {
+	Error  string
+	Fields []fieldError `json:",omitempty"`
+}
+
+// fieldError is a field of a posted configuration that didn't pass
+// validation.
+type fieldError struct {
+	Field string
+	Error string
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, apiError{Error: err.Error()})
+}
+
+// login exchanges the one-time token for a session.  ok is false if
+// the token is wrong or has been used already.
+func (f *Frontend) login(token string) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.tokenUsed || subtle.ConstantTimeCompare([]byte(token), []byte(f.token)) != 1 {
+		return "", false
+	}
+	session, err := randomID()
+	if err != nil {
+		return "", false
+	}
+	f.tokenUsed = true
+	f.sessions[session] = true
+	return session, true
+}
+
+// session returns the session of the request, from the cookie set
+// for browsers or from a bearer token.
+func session(r *http.Request) string {
+	if h := r.Header.Get("Authorization"); strings.HasPrefix(h, "Bearer ") {
+		return strings.TrimPrefix(h, "Bearer ")
+	}
+	if c, err := r.Cookie(sessionCookie); err == nil {
+		return c.Value
+	}
+	return ""
+}
+
+// authorized only lets requests with a session through.
+func (f *Frontend) authorized(h http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		ok := f.sessions[session(r)]
+		f.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("log in with the token shown on the console"))
+			return
+		}
+		h(w, r)
+	})
+}
+
+// setSessionCookie hands the browser its session, which it only
+// sends back over TLS if that is how it arrived.
+func setSessionCookie(w http.ResponseWriter, r *http.Request, s string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    s,
+		Path:     "/",
+		Secure:   r.TLS != nil,
+		HttpOnly: true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+func (f *Frontend) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("use POST"))
+		return
+	}
+	var req struct{ Token string }
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	s, ok := f.login(req.Token)
+	if !ok {
+		writeError(w, http.StatusForbidden, fmt.Errorf("the token is wrong or has been used already"))
+		return
+	}
+	setSessionCookie(w, r, s)
+	writeJSON(w, http.StatusOK, struct{ Session string }{s})
+}
+
+// handlePage serves the UI.  Opening the page with the token logs
+// the browser in, and the token is then dropped from the address.
+func (f *Frontend) handlePage(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if token := r.URL.Query().Get("token"); token != "" {
+		if s, ok := f.login(token); ok {
+			setSessionCookie(w, r, s)
+		}
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, page)
+}
+
+type state struct {
+	Phase    string
+	Progress progress
+}
+
+func (f *Frontend) handleState(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	s := state{f.phase, f.progress}
+	f.mu.Unlock()
+	writeJSON(w, http.StatusOK, s)
+}
+
+func (f *Frontend) handleSystem(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, f.sysinfo.Report())
+}
+
+// withoutSecrets returns a copy of c that can be sent back to a
+// client.
+func withoutSecrets(c *config.Config) config.Config {
+	out := *c
+	out.RootPassword, out.RootPasswordHash = "", ""
+	out.Layout.Passphrase = ""
+	out.Users = append([]config.User{}, c.Users...)
+	for n := range out.Users {
+		out.Users[n].Password, out.Users[n].PasswordHash = "", ""
+	}
+	out.Network.Wifi = append([]config.Wifi{}, c.Network.Wifi...)
+	for n := range out.Network.Wifi {
+		out.Network.Wifi[n].PSK = ""
+	}
+	return out
+}
+
+// handleConfig returns the configuration so far, or takes the one
+// to install with.  A posted configuration is validated and the
+// problems returned with status 422.
+func (f *Frontend) handleConfig(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		f.mu.Lock()
+		c := f.config
+		f.mu.Unlock()
+		if c == nil {
+			c = new(config.Config)
+		}
+		writeJSON(w, http.StatusOK, withoutSecrets(c))
+		return
+	case http.MethodPost, http.MethodPut:
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("use GET or POST"))
+		return
+	}
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	c := new(config.Config)
+	if err := dec.Decode(c); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := c.Validate(); err != nil {
+		resp := apiError{Error: err.Error()}
+		if errs, ok := err.(config.ValidationErrors); ok {
+			for _, e := range errs {
+				resp.Fields = append(resp.Fields, fieldError{e.Field, e.Err.Error()})
+			}
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	if err := f.transition(phaseConfigure, phaseConfirm); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	f.mu.Lock()
+	f.config = c
+	f.mu.Unlock()
+
+	f.configs <- c
+	writeJSON(w, http.StatusOK, struct{ Summary string }{c.String()})
+}
+
+// handleConfirm starts the installation, or calls it off.
+func (f *Frontend) handleConfirm(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("use POST"))
+		return
+	}
+	var req struct{ Proceed bool }
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	next := phaseInstalling
+	if !req.Proceed {
+		next = phaseAborted
+	}
+	if err := f.transition(phaseConfirm, next); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	f.confirms <- req.Proceed
+	writeJSON(w, http.StatusOK, struct{ Proceed bool }{req.Proceed})
+}
@@ -0,0 +1,105 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// An event is sent to the clients as the installation goes on.  Type
+// is one of phase, progress, output or error.
+type event struct {
+	Type string
+	Data interface{}
+}
+
+// publish records an event and passes it on to every client that is
+// listening.  Clients that can't keep up are cut off, they can
+// reconnect and be sent everything again.
+func (f *Frontend) publish(e event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, e)
+	for l := range f.listeners {
+		select {
+		case l <- e:
+		default:
+			close(l)
+			delete(f.listeners, l)
+		}
+	}
+}
+
+// subscribe returns the events so far and a channel for those still
+// to come, which is nil once the frontend has shut down.  Streams
+// that are subscribed are waited for when shutting down.
+func (f *Frontend) subscribe() ([]event, chan event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	past := append([]event{}, f.events...)
+	if f.closed {
+		return past, nil
+	}
+	l := make(chan event, 100)
+	f.listeners[l] = true
+	f.streams.Add(1)
+	return past, l
+}
+
+func (f *Frontend) unsubscribe(l chan event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.listeners[l] {
+		close(l)
+		delete(f.listeners, l)
+	}
+	f.streams.Done()
+}
+
+// handleEvents streams the installation as Server-Sent Events.  Each
+// event has the JSON of its data as the data field.  The stream
+// starts with everything that has happened so far and ends when the
+// installation does.
+func (f *Frontend) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("streaming is not supported"))
+		return
+	}
+	past, l := f.subscribe()
+	if l != nil {
+		defer f.unsubscribe(l)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range past {
+		writeEvent(w, e)
+	}
+	flusher.Flush()
+	if l == nil {
+		return
+	}
+
+	for {
+		select {
+		case e, ok := <-l:
+			if !ok {
+				return
+			}
+			writeEvent(w, e)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e event) {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+}
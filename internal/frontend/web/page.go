@@ -0,0 +1,170 @@
+package web
+
+// page is the whole of the web UI.  It only uses the API, so anything
+// it does can also be done with curl.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Void Linux Installer</title>
+<style>
+body { font-family: sans-serif; max-width: 60em; margin: 1em auto; padding: 0 1em; }
+textarea { width: 100%; height: 25em; font-family: monospace; }
+pre { background: #eee; padding: .5em; overflow: auto; max-height: 30em; }
+.error { color: #b00; }
+.hidden { display: none; }
+progress { width: 100%; }
+</style>
+</head>
+<body>
+<h1>Void Linux Installer</h1>
+
+<section id="login" class="hidden">
+<p>Enter the token shown on the console of the machine.</p>
+<input id="token" size="40"> <button id="login-button">Log in</button>
+<p id="login-error" class="error"></p>
+</section>
+
+<section id="configure" class="hidden">
+<h2>System</h2>
+<pre id="system"></pre>
+<h2>Disks that can be installed to</h2>
+<pre id="disks"></pre>
+<h2>Configuration</h2>
+<p>The configuration is a JSON document.  Passwords may be given in the clear or already hashed.</p>
+<textarea id="config"></textarea>
+<p><button id="submit">Check and continue</button></p>
+<ul id="config-errors" class="error"></ul>
+</section>
+
+<section id="confirm" class="hidden">
+<h2>Confirm</h2>
+<pre id="summary"></pre>
+<p>Data on the target may be lost.</p>
+<button id="proceed">Install</button> <button id="abort">Cancel</button>
+</section>
+
+<section id="install" class="hidden">
+<h2 id="status">Installing</h2>
+<progress id="progress" value="0" max="1"></progress>
+<pre id="log"></pre>
+</section>
+
+<script>
+function $(id) { return document.getElementById(id); }
+
+function show(id) {
+	for (const s of ["login", "configure", "confirm", "install"]) {
+		$(s).classList.toggle("hidden", s !== id);
+	}
+}
+
+async function api(method, path, body) {
+	const resp = await fetch(path, {
+		method: method,
+		headers: {"Content-Type": "application/json"},
+		body: body === undefined ? undefined : JSON.stringify(body),
+	});
+	return {status: resp.status, body: await resp.json()};
+}
+
+async function start() {
+	const state = await api("GET", "/api/state");
+	if (state.status === 401) {
+		show("login");
+		return;
+	}
+	switch (state.body.Phase) {
+	case "configure":
+		const sys = await api("GET", "/api/system");
+		$("system").textContent = sys.body.Summary;
+		$("disks").textContent = sys.body.Disks.map(d => [
+			d.Path,
+			(d.SizeBytes / 2**30).toFixed(1) + " GiB",
+			d.Model,
+			d.Transport && "(" + d.Transport + ")",
+			d.Partitions.length + " partitions",
+		].filter(Boolean).join(" ")).join("\n");
+		const cfg = await api("GET", "/api/config");
+		$("config").value = JSON.stringify(cfg.body, null, 2);
+		show("configure");
+		break;
+	case "confirm":
+		show("confirm");
+		break;
+	default:
+		follow();
+	}
+}
+
+$("login-button").onclick = async () => {
+	const resp = await api("POST", "/api/login", {Token: $("token").value.trim()});
+	if (resp.status !== 200) {
+		$("login-error").textContent = resp.body.Error;
+		return;
+	}
+	start();
+};
+
+$("submit").onclick = async () => {
+	$("config-errors").innerHTML = "";
+	let cfg;
+	try {
+		cfg = JSON.parse($("config").value);
+	} catch (e) {
+		$("config-errors").textContent = e.message;
+		return;
+	}
+	const resp = await api("POST", "/api/config", cfg);
+	if (resp.status !== 200) {
+		for (const e of resp.body.Fields || [{Field: "", Error: resp.body.Error}]) {
+			const li = document.createElement("li");
+			li.textContent = e.Field ? e.Field + ": " + e.Error : e.Error;
+			$("config-errors").appendChild(li);
+		}
+		return;
+	}
+	$("summary").textContent = resp.body.Summary;
+	show("confirm");
+};
+
+async function confirm(proceed) {
+	await api("POST", "/api/confirm", {Proceed: proceed});
+	follow();
+}
+$("proceed").onclick = () => confirm(true);
+$("abort").onclick = () => confirm(false);
+
+function follow() {
+	show("install");
+	const events = new EventSource("/api/events");
+	const log = (text, error) => {
+		const line = document.createElement("div");
+		line.textContent = text;
+		if (error) line.className = "error";
+		$("log").appendChild(line);
+		$("log").scrollTop = $("log").scrollHeight;
+	};
+	events.addEventListener("output", e => log(JSON.parse(e.data)));
+	events.addEventListener("error", e => { if (e.data) log(JSON.parse(e.data), true); });
+	events.addEventListener("progress", e => {
+		const p = JSON.parse(e.data);
+		$("status").textContent = "Step " + p.Step + " of " + p.Total + ": " + p.Name;
+		$("progress").max = p.Total;
+		$("progress").value = p.Step - 0.5;
+	});
+	events.addEventListener("phase", e => {
+		const phase = JSON.parse(e.data);
+		if (phase === "finished" || phase === "aborted") {
+			$("status").textContent = "The installation has " + phase;
+			$("progress").value = $("progress").max;
+			events.close();
+		}
+	});
+}
+
+start();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	tlsCert = flag.String("tls-cert", "", "Certificate the http frontend serves, a self-signed one is made if not given")
+	tlsKey  = flag.String("tls-key", "", "Private key of the certificate given with -tls-cert")
+)
+
+// certificate returns the certificate given with -tls-cert and
+// -tls-key, or else a self-signed one made for this run.  The token
+// and everything after it only ever travel encrypted, and a client
+// that can't check a self-signed certificate against a CA can check
+// its fingerprint against the one on the console instead.
+func certificate() (tls.Certificate, error) {
+	if *tlsCert != "" || *tlsKey != "" {
+		return tls.LoadX509KeyPair(*tlsCert, *tlsKey)
+	}
+	return selfSigned()
+}
+
+func selfSigned() (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	name := "vInstaller"
+	if h, err := os.Hostname(); err == nil {
+		name = h
+	}
+	template := x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(30 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+
+// fingerprint returns the SHA-256 fingerprint of a certificate the
+// way browsers show it.
+func fingerprint(cert tls.Certificate) string {
+	sum := sha256.Sum256(cert.Certificate[0])
+	hex := make([]string, len(sum))
+	for n, b := range sum {
+		hex[n] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(hex, ":")
+}
@@ -0,0 +1,284 @@
+// Package web provides the http frontend, which serves a web UI and
+// a JSON API so that machines with nothing but a serial console and
+// a network card can be installed from another machine.
+//
+// Access is protected by a token that is printed on the console.  The
+// token can only be used once, to log in, after which the session
+// it was exchanged for identifies the client.  Both only travel over
+// TLS, with the certificate given by -tls-cert and -tls-key or else a
+// self-signed one whose fingerprint is printed next to the token, so
+// that the client can tell it is talking to the installer and not to
+// something else on the network.  Handler exposes the whole of the
+// frontend so that it can be driven with httptest.
+//
+// The API is:
+//
+//	POST /api/login     {"Token": "..."}, returns {"Session": "..."}
+//	GET  /api/state     the phase of the installation and its progress
+//	GET  /api/system    the hardware of the machine and the disks it can
+//	                    be installed to
+//	GET  /api/config    the configuration so far, without secrets
+//	POST /api/config    a config.Config, which is validated and used
+//	POST /api/confirm   {"Proceed": true} starts the installation
+//	GET  /api/events    the installation as Server-Sent Events
+package web
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/frontend"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/installer"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+var listen = flag.String("listen", ":8080", "Address the http frontend listens on")
+
+// The phases an installation goes through, as reported by the API.
+const (
+	phaseConfigure  = "configure"
+	phaseConfirm    = "confirm"
+	phaseInstalling = "installing"
+	phaseFinished   = "finished"
+	phaseAborted    = "aborted"
+)
+
+// drainTimeout is how long clients get to receive the end of the
+// installation before the frontend returns.
+const drainTimeout = 5 * time.Second
+
+// Frontend serves the installer over HTTP.
+type Frontend struct {
+	sysinfo     *sysinfo.System
+	server      *http.Server
+	fingerprint string
+
+	configs  chan *config.Config
+	confirms chan bool
+
+	mu        sync.Mutex
+	token     string
+	tokenUsed bool
+	sessions  map[string]bool
+	phase     string
+	config    *config.Config
+	progress  progress
+	events    []event
+	listeners map[chan event]bool
+	closed    bool
+	streams   sync.WaitGroup
+}
+
+// progress is how far along the installation is.
+type progress struct {
+	Step   int
+	Total  int
+	Name   string
+	Failed bool
+}
+
+// New returns a frontend that listens on the address given with
+// -listen, serving HTTPS.
+func New() (frontend.InstallerFrontend, error) {
+	f, err := NewFrontend(nil)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := certificate()
+	if err != nil {
+		return nil, err
+	}
+	f.fingerprint = fingerprint(cert)
+	f.server = &http.Server{
+		Addr:      *listen,
+		Handler:   f.Handler(),
+		TLSConfig: &tls.Config{Certificates: []tls.Certificate{cert}},
+	}
+	return f, nil
+}
+
+// NewFrontend returns a frontend that isn't listening anywhere, for
+// use with Handler.  The machine is described by sys, which is
+// discovered if nil.
+func NewFrontend(sys *sysinfo.System) (*Frontend, error) {
+	token, err := randomID()
+	if err != nil {
+		return nil, err
+	}
+	return &Frontend{
+		sysinfo:   sys,
+		configs:   make(chan *config.Config),
+		confirms:  make(chan bool),
+		token:     token,
+		sessions:  make(map[string]bool),
+		phase:     phaseConfigure,
+		listeners: make(map[chan event]bool),
+	}, nil
+}
+
+func init() {
+	frontend.Register(frontend.Registration{
+		Name:         "http",
+		Description:  "Serve a web page and API on -listen to install from another machine",
+		Capabilities: frontend.Interactive | frontend.Remote,
+		Factory:      New,
+		Detect:       listenGiven,
+	})
+}
+
+// listenGiven reports whether -listen was given, which asks for this
+// frontend.
+func listenGiven() bool {
+	given := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "listen" {
+			given = true
+		}
+	})
+	return given
+}
+
+// Token returns the one-time token needed to log in.
+func (f *Frontend) Token() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.token
+}
+
+func randomID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// transition moves the installation from one phase to the next.  The
+// phase only ever moves forward, so a request that comes too late or
+// twice gets an error.
+func (f *Frontend) transition(from, to string) error {
+	f.mu.Lock()
+	if f.phase != from {
+		phase := f.phase
+		f.mu.Unlock()
+		return fmt.Errorf("the installation is in the %s phase, not %s", phase, from)
+	}
+	f.phase = to
+	f.mu.Unlock()
+	f.publish(event{"phase", to})
+	return nil
+}
+
+// GetInstallerConfig starts serving, unless only Handler is used,
+// and waits for a valid configuration to be posted.  If the address
+// can't be bound that is returned, rather than waiting for a
+// configuration that can't arrive.
+func (f *Frontend) GetInstallerConfig() (*config.Config, error) {
+	if f.sysinfo == nil {
+		f.sysinfo = sysinfo.DiscoverHardware()
+	}
+	if f.server != nil {
+		l, err := net.Listen("tcp", f.server.Addr)
+		if err != nil {
+			log.Printf("The http frontend can't listen: %v", err)
+			return nil, err
+		}
+		go func() {
+			if err := f.server.ServeTLS(l, "", ""); err != nil && err != http.ErrServerClosed {
+				log.Printf("The http frontend stopped: %v", err)
+			}
+		}()
+		fmt.Printf("The installer is waiting at https://%s/\n", *listen)
+		fmt.Printf("Its certificate has the SHA-256 fingerprint %s\n", f.fingerprint)
+		fmt.Printf("Log in with the one-time token %s\n", f.Token())
+	}
+	return <-f.configs, nil
+}
+
+// ConfirmInstallation waits for the client to start or call off the
+// installation.
+func (f *Frontend) ConfirmInstallation() error {
+	if <-f.confirms {
+		return nil
+	}
+	f.shutdown()
+	return frontend.ErrInstallationAborted
+}
+
+// ShowInstallationProgress passes the installation on to the clients
+// as events.
+func (f *Frontend) ShowInstallationProgress(output <-chan i18n.Message, errors <-chan error, done <-chan bool) {
+	for output != nil || errors != nil {
+		select {
+		case o, ok := <-output:
+			if !ok {
+				output = nil
+				continue
+			}
+			fmt.Println(o)
+			if n, total, name, ok := installer.ParseProgress(o); ok {
+				f.mu.Lock()
+				f.progress.Step, f.progress.Total, f.progress.Name = n, total, name
+				p := f.progress
+				f.mu.Unlock()
+				f.publish(event{"progress", p})
+			}
+			f.publish(event{"output", o.String()})
+		case e, ok := <-errors:
+			if !ok {
+				errors = nil
+				continue
+			}
+			fmt.Println(e)
+			f.mu.Lock()
+			f.progress.Failed = true
+			f.mu.Unlock()
+			f.publish(event{"error", e.Error()})
+		case <-done:
+			// The installer keeps reporting until it closes
+			// its channels.
+			done = nil
+		}
+	}
+	f.transition(phaseInstalling, phaseFinished)
+	f.shutdown()
+}
+
+// shutdown ends the event streams, gives the clients a moment to
+// receive what is left, and stops serving.
+func (f *Frontend) shutdown() {
+	f.mu.Lock()
+	f.closed = true
+	for l := range f.listeners {
+		close(l)
+		delete(f.listeners, l)
+	}
+	f.mu.Unlock()
+
+	finished := make(chan struct{})
+	go func() {
+		f.streams.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(drainTimeout):
+	}
+
+	if f.server != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		defer cancel()
+		f.server.Shutdown(ctx)
+	}
+}
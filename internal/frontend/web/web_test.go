@@ -0,0 +1,216 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// client talks to the API of a frontend served by httptest.
+type client struct {
+	t       *testing.T
+	url     string
+	session string
+}
+
+// do sends body, if any, as JSON and decodes the response into out,
+// if given, returning the status.
+func (c *client) do(method, path string, body, out interface{}) int {
+	c.t.Helper()
+	var b bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&b).Encode(body); err != nil {
+			c.t.Fatal(err)
+		}
+	}
+	req, err := http.NewRequest(method, c.url+path, &b)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	if c.session != "" {
+		req.Header.Set("Authorization", "Bearer "+c.session)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			c.t.Fatalf("%s %s: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func (c *client) login(token string) int {
+	c.t.Helper()
+	var resp struct{ Session string }
+	status := c.do("POST", "/api/login", struct{ Token string }{token}, &resp)
+	if status == http.StatusOK {
+		c.session = resp.Session
+	}
+	return status
+}
+
+func TestLogin(t *testing.T) {
+	f, err := NewFrontend(&sysinfo.System{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(f.Handler())
+	defer srv.Close()
+
+	c := &client{t: t, url: srv.URL}
+	if status := c.do("GET", "/api/state", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("state without a session: status %d, want %d", status, http.StatusUnauthorized)
+	}
+	if status := c.login("wrong"); status != http.StatusForbidden {
+		t.Errorf("login with a wrong token: status %d, want %d", status, http.StatusForbidden)
+	}
+	if status := c.login(f.Token()); status != http.StatusOK {
+		t.Fatalf("login: status %d, want %d", status, http.StatusOK)
+	}
+	var s state
+	if status := c.do("GET", "/api/state", nil, &s); status != http.StatusOK || s.Phase != phaseConfigure {
+		t.Errorf("state: status %d, phase %q", status, s.Phase)
+	}
+	var sys map[string]interface{}
+	if status := c.do("GET", "/api/system", nil, &sys); status != http.StatusOK {
+		t.Errorf("system: status %d", status)
+	}
+	if disks, ok := sys["Disks"].([]interface{}); !ok || len(disks) != 0 {
+		t.Errorf("system without disks: Disks %v, want []", sys["Disks"])
+	}
+
+	other := &client{t: t, url: srv.URL}
+	if status := other.login(f.Token()); status != http.StatusForbidden {
+		t.Errorf("login with a used token: status %d, want %d", status, http.StatusForbidden)
+	}
+	other.session = "made-up"
+	if status := other.do("GET", "/api/config", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("config with a made up session: status %d, want %d", status, http.StatusUnauthorized)
+	}
+}
+
+func TestInstallation(t *testing.T) {
+	f, err := NewFrontend(&sysinfo.System{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(f.Handler())
+	defer srv.Close()
+	c := &client{t: t, url: srv.URL}
+	if status := c.login(f.Token()); status != http.StatusOK {
+		t.Fatalf("login: status %d", status)
+	}
+
+	configs := make(chan *config.Config, 1)
+	go func() {
+		cfg, _ := f.GetInstallerConfig()
+		configs <- cfg
+	}()
+
+	var bad apiError
+	status := c.do("POST", "/api/config", config.Config{Hostname: "not a hostname"}, &bad)
+	if status != http.StatusUnprocessableEntity {
+		t.Fatalf("invalid config: status %d, want %d", status, http.StatusUnprocessableEntity)
+	}
+	if len(bad.Fields) != 1 || bad.Fields[0].Field != "Hostname" {
+		t.Errorf("invalid config: fields %v, want Hostname", bad.Fields)
+	}
+
+	cfg := config.Config{
+		Hostname:     "void",
+		RootPassword: "root-secret",
+		Users:        []config.User{{Username: "alice", Password: "user-secret"}},
+		Network:      config.Network{Wifi: []config.Wifi{{SSID: "home", PSK: "wifi-secret"}}},
+	}
+	if status := c.do("POST", "/api/config", cfg, nil); status != http.StatusOK {
+		t.Fatalf("config: status %d", status)
+	}
+	if got := <-configs; got == nil || got.Hostname != "void" {
+		t.Fatalf("GetInstallerConfig returned %v", got)
+	}
+	if status := c.do("POST", "/api/config", cfg, nil); status != http.StatusConflict {
+		t.Errorf("config posted twice: status %d, want %d", status, http.StatusConflict)
+	}
+
+	var back map[string]interface{}
+	if status := c.do("GET", "/api/config", nil, &back); status != http.StatusOK {
+		t.Fatalf("get config: status %d", status)
+	}
+	b, _ := json.Marshal(back)
+	for _, secret := range []string{"root-secret", "user-secret", "wifi-secret"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("GET /api/config returned %s", secret)
+		}
+	}
+	if !strings.Contains(string(b), "alice") {
+		t.Errorf("GET /api/config left out the users: %s", b)
+	}
+
+	confirmed := make(chan error, 1)
+	go func() { confirmed <- f.ConfirmInstallation() }()
+	if status := c.do("POST", "/api/confirm", struct{ Proceed bool }{true}, nil); status != http.StatusOK {
+		t.Fatalf("confirm: status %d", status)
+	}
+	if err := <-confirmed; err != nil {
+		t.Fatalf("ConfirmInstallation: %v", err)
+	}
+
+	req, _ := http.NewRequest("GET", srv.URL+"/api/events", nil)
+	req.Header.Set("Authorization", "Bearer "+c.session)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	output := make(chan i18n.Message)
+	errs := make(chan error)
+	done := make(chan bool)
+	finished := make(chan struct{})
+	go func() {
+		f.ShowInstallationProgress(output, errs, done)
+		close(finished)
+	}()
+	output <- i18n.M("installer.step", 1, 1, "base-system")
+	output <- i18n.Text("installing")
+	errs <- errors.New("something broke")
+	close(done)
+	close(output)
+	close(errs)
+
+	stream, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-finished
+	for _, want := range []string{
+		"event: phase\ndata: \"installing\"\n\n",
+		"event: progress\ndata: {\"Step\":1,\"Total\":1,\"Name\":\"base-system\",\"Failed\":false}\n\n",
+		"event: output\ndata: \"installing\"\n\n",
+		"event: error\ndata: \"something broke\"\n\n",
+		"event: phase\ndata: \"finished\"\n\n",
+	} {
+		if !strings.Contains(string(stream), want) {
+			t.Errorf("the event stream lacks %q:\n%s", want, stream)
+		}
+	}
+
+	var s state
+	c.do("GET", "/api/state", nil, &s)
+	if s.Phase != phaseFinished || !s.Progress.Failed {
+		t.Errorf("state after the installation: %+v", s)
+	}
+}
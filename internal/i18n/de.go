@@ -0,0 +1,330 @@
+package i18n
+
+var german = Catalogue{
+	"installer.step":                    "Schritt %s von %s: %s",
+	"installer.resuming":                "Installation wird nach Schritt %q fortgesetzt",
+	"installer.completed":               "Installation nach %s abgeschlossen",
+	"installer.cancelled":               "Installation in Schritt %q abgebrochen",
+	"installer.failed":                  "Installation in Schritt %q fehlgeschlagen: %s",
+	"installer.no-steps":                "  Es wurde kein Schritt abgeschlossen, %s kann noch unvollständige Dateien enthalten",
+	"installer.completed-steps":         "  Abgeschlossene Schritte: %s",
+	"installer.partial":                 "  %s enthält ein unvollständig installiertes System",
+	"installer.rolled-back":             "  Die Konfiguration des Ziels wurde zurückgesetzt:",
+	"installer.cleanup-failed":          "  Das Aufräumen ist fehlgeschlagen, %s muss von Hand erledigt werden",
+	"installer.formatting":              "%s wird als %s formatiert",
+	"installer.erasing":                 "%s wird gelöscht und als %s eingeteilt",
+	"installer.dry-run":                 "Probelauf, es wird nichts verändert.  Diese Befehle würden ausgeführt:",
+	"installer.opening":                 "%s wird geöffnet",
+	"installer.initramfs":               "Die Initramfs wird zum Entsperren des Wurzeldateisystems eingerichtet",
+	"installer.initramfs-done":          "  Die Initramfs wurde neu erzeugt",
+	"installer.keys":                    "Schlüssel werden installiert",
+	"installer.packages":                "Zusätzliche Pakete werden installiert",
+	"installer.configuring":             "%s wird eingerichtet",
+	"installer.configured":              "  %s wurde eingerichtet",
+	"installer.names":                   "Netzwerknamen werden eingerichtet",
+	"installer.localtime":               "  /etc/localtime verweist auf %s",
+	"installer.xkb-guess":               "  Für %s ist keine X11-Tastaturbelegung bekannt, sie wird geraten",
+	"installer.users":                   "Benutzerkonten werden angelegt",
+	"installer.users-added":             "  Benutzerkonten angelegt",
+	"installer.user-exists":             "  %s existiert bereits",
+	"installer.locked":                  "  %s wurde gesperrt",
+	"installer.no-home":                 "  %s hat kein Home-Verzeichnis, SSH-Schlüssel werden nicht installiert",
+	"installer.ssh-keys":                "  %s SSH-Schlüssel für %s installiert",
+	"installer.no-group":                "  Die Gruppe %s existiert nicht und wird übersprungen",
+	"installer.group-created":           "  Gruppe %s angelegt",
+	"installer.root":                    "Das root-Konto wird eingerichtet",
+	"installer.root-not-locked":         "  root wird nicht gesperrt, kein Benutzer ist in der Gruppe wheel",
+	"installer.root-shell":              "  Die Shell von root ist %s",
+	"installer.network":                 "Das Netzwerk wird eingerichtet",
+	"installer.services":                "Dienste werden aktiviert",
+	"installer.service-enabled":         "  %s in %s aktiviert",
+	"installer.service-down":            "  %s in %s aktiviert, nicht gestartet",
+	"installer.service-already-enabled": "  %s ist in %s bereits aktiviert",
+	"installer.service-disabled":        "  %s in %s deaktiviert",
+	"installer.files":                   "Zusätzliche Dateien werden geschrieben",
+
+	"prompt.yes":                    "ja",
+	"prompt.no":                     "nein",
+	"prompt.welcome":                "Willkommen beim Void Linux Installer",
+	"prompt.inspecting":             "Bitte warten, das System wird untersucht...",
+	"prompt.defaults-help":          "Drücken Sie Enter, um den Wert in Klammern zu behalten, oder geben Sie - ein, um ihn zu löschen.",
+	"prompt.choice":                 "Auswahl",
+	"prompt.count":                  "%s in der Liste:",
+	"prompt.count-titled":           "%s, %s in der Liste:",
+	"prompt.echo":                   "Gewählt: %s",
+	"prompt.echo-empty":             "Nichts gewählt",
+	"prompt.checked":                "ausgewählt",
+	"prompt.unchecked":              "nicht ausgewählt",
+	"prompt.keep":                   "Behalten?",
+	"prompt.use-anyway":             "Trotzdem verwenden?",
+	"prompt.proceed-question":       "Möchten Sie mit der Installation fortfahren?",
+	"prompt.proceed":                "Fortfahren (ja/nein)",
+	"prompt.review":                 "Bitte überprüfen Sie Ihre Konfiguration:",
+	"prompt.review-section":         "Zu ändernder Abschnitt (leer zum Fortfahren)",
+	"prompt.review-problems":        "Bitte beheben Sie zuerst die mit ! markierten Probleme",
+	"prompt.review-choose":          "Wählen Sie einen Abschnitt von 1 bis %s",
+	"prompt.section-disk":           "Festplatte",
+	"prompt.section-hostname":       "Rechnername",
+	"prompt.section-timezone":       "Zeitzone",
+	"prompt.section-locale":         "Gebietsschema",
+	"prompt.section-bootloader":     "Bootloader",
+	"prompt.section-keyboard":       "Tastatur",
+	"prompt.section-packages":       "Pakete",
+	"prompt.section-network":        "Netzwerk",
+	"prompt.section-console":        "Konsole",
+	"prompt.section-users":          "Benutzer",
+	"prompt.section-root-password":  "root-Passwort",
+	"prompt.section-root-shell":     "root-Shell",
+	"prompt.summary-layout":         "%s gelöscht, %s",
+	"prompt.summary-filesystem":     "%s auf %s",
+	"prompt.summary-mounted":        "Dateisysteme bereits eingehängt",
+	"prompt.summary-no-grub":        "nicht installiert",
+	"prompt.summary-grub":           "GRUB auf %s",
+	"prompt.summary-grub-graphical": "GRUB auf %s, grafisch",
+	"prompt.summary-wifi":           "WLAN %s",
+	"prompt.summary-clock":          "Uhr %s",
+	"prompt.summary-font":           "Schrift %s",
+	"prompt.summary-ttys":           "%s TTYs",
+	"prompt.summary-rc-vars":        "%s rc.conf-Variablen",
+	"prompt.summary-defaults":       "Standardwerte",
+	"prompt.summary-locked":         "gesperrt",
+	"prompt.summary-not-set":        "nicht gesetzt",
+	"prompt.summary-set":            "gesetzt",
+	"prompt.console-skip":           "Erweiterte Konsoleneinstellungen vornehmen?",
+	"prompt.disks":                  "Festplatten:",
+	"prompt.disk-live":              "(Von %s läuft der Installer, darauf kann nicht installiert werden)",
+	"prompt.disk-mounted":           "Auf bereits im Ziel eingehängte Dateisysteme installieren",
+	"prompt.disk":                   "Festplatte für die Installation (leer, um die Auswahl zu behalten)",
+	"prompt.disk-choose":            "Wählen Sie eine Festplatte von 1 bis %s, oder m",
+	"prompt.layouts":                "Aufteilungen:",
+	"prompt.layout":                 "Aufteilung",
+	"prompt.layout-choose":          "Wählen Sie eine Aufteilung von 1 bis %s",
+	"prompt.layout-plan":            "%s wird so aufgeteilt:",
+	"prompt.passphrase":             "Passphrase zum Entsperren von %s",
+	"prompt.passphrase-again":       "Passphrase wiederholen",
+	"prompt.passphrase-weak":        "Diese Passphrase ist schwach: %s",
+	"prompt.passphrase-mismatch":    "Die Passphrasen stimmen nicht überein",
+	"prompt.erased":                 "Dies wird gelöscht:",
+	"prompt.erase":                  "%s löschen?",
+	"prompt.these-partitions":       "diese Partitionen",
+	"prompt.cfdisk":                 "cfdisk schreibt seine Änderungen an %s, sobald es dazu aufgefordert wird, noch bevor die Installation bestätigt ist, und was auf den geänderten Partitionen war, geht verloren.",
+	"prompt.mount-point":            "Einhängepunkt, oder swap (leer, um sie nicht zu verwenden)",
+	"prompt.mount-point-absolute":   "ein Einhängepunkt beginnt mit /",
+	"prompt.filesystem-type":        "Dateisystemtyp",
+	"prompt.format":                 "%s formatieren?",
+	"prompt.hostname":               "Rechnername des Systems",
+	"prompt.domain":                 "Domäne (leer für keine)",
+	"prompt.timezone":               "Geben Sie Ihre Zeitzone ein",
+	"prompt.timezone-browse":        "Geben Sie Ihre Zeitzone ein (? zum Blättern, /Name zum Suchen)",
+	"prompt.timezone-regions":       "Regionen:",
+	"prompt.timezone-other":         "Andere",
+	"prompt.timezone-zones":         "Zeitzonen:",
+	"prompt.timezone-none":          "Keine passenden Zeitzonen",
+	"prompt.timezone-matching":      "Passende Zeitzonen:",
+	"prompt.locale":                 "Bitte geben Sie Ihr glibc-Gebietsschema ein",
+	"prompt.grub":                   "GRUB verwenden?",
+	"prompt.grub-graphical":         "Grafisches GRUB verwenden?",
+	"prompt.grub-disk":              "GRUB installieren auf",
+	"prompt.grub-no-disk":           "für GRUB wird eine Festplatte benötigt",
+	"prompt.keyboard":               "Bitte geben Sie Ihre Tastaturbelegung ein (? zum Auflisten, /Name zum Suchen)",
+	"prompt.xorg":                   "Eine grafische Umgebung (xorg) installieren?",
+	"prompt.packages":               "Zusätzliche Pakete (durch Leerzeichen getrennt)",
+	"prompt.network-manager":        "Netzwerkverwaltung, dhcpcd oder NetworkManager",
+	"prompt.interfaces-configured":  "Eingerichtete Schnittstellen:",
+	"prompt.interfaces":             "Netzwerkschnittstellen:",
+	"prompt.interface":              "Einzurichtende Schnittstelle (leer für DHCP auf allen)",
+	"prompt.address":                "Adresse in CIDR-Notation (leer für DHCP)",
+	"prompt.gateway":                "Gateway (leer für keines)",
+	"prompt.dns":                    "DNS-Server (durch Leerzeichen getrennt, leer für DHCP)",
+	"prompt.wifi-configured":        "WLAN-Netze:",
+	"prompt.wifi":                   "Zu verbindendes WLAN (leer zum Beenden)",
+	"prompt.wifi-passphrase":        "Passphrase (leer, wenn offen)",
+	"prompt.clock":                  "Hardware-Uhr, UTC oder localtime (leer für UTC)",
+	"prompt.font":                   "Konsolenschrift (leer für die Voreinstellung)",
+	"prompt.font-map":               "Konsolen-Map (leer für keine)",
+	"prompt.font-unimap":            "Konsolen-Unimap (leer für keine)",
+	"prompt.ttys":                   "Anzahl der TTYs (leer für die Voreinstellung)",
+	"prompt.rc-vars":                "Zusätzliche rc.conf-Variablen:",
+	"prompt.rc-var":                 "Zusätzliche rc.conf-Variable (NAME=Wert, NAME= zum Entfernen, leer zum Beenden)",
+	"prompt.rc-var-format":          "Variablen müssen als NAME=Wert angegeben werden",
+	"prompt.users":                  "Benutzer:",
+	"prompt.users-edit":             "Nummer zum Bearbeiten, a zum Hinzufügen, d und eine Nummer zum Löschen, leer wenn fertig",
+	"prompt.users-choose":           "Wählen Sie einen Benutzer von 1 bis %s",
+	"prompt.user-add":               "Möchten Sie einen Benutzer hinzufügen?",
+	"prompt.user-delete":            "Den Benutzer %s löschen?",
+	"prompt.username":               "Benutzername",
+	"prompt.gecos":                  "Name des Benutzers",
+	"prompt.shell":                  "Login-Shell (leer für die Voreinstellung)",
+	"prompt.root-shell":             "Shell für root (leer für die Voreinstellung)",
+	"prompt.password":               "Passwort für %s (! zum Sperren, oder einen Hash einfügen)",
+	"prompt.password-keep":          "Passwort für %s (! zum Sperren, oder einen Hash einfügen) (leer zum Behalten)",
+	"prompt.password-again":         "Passwort wiederholen",
+	"prompt.password-none":          "%s ohne Passwort lassen?",
+	"prompt.password-weak":          "Dieses Passwort ist schwach: %s",
+	"prompt.password-mismatch":      "Die Passwörter stimmen nicht überein",
+	"prompt.groups":                 "Gruppen:",
+	"prompt.groups-toggle":          "Nummern zum Umschalten, +Name für eine weitere Gruppe, leer wenn fertig",
+	"prompt.groups-choose":          "%s: Wählen Sie eine Gruppe von 1 bis %s",
+	"prompt.ssh-keys":               "SSH-Schlüssel:",
+	"prompt.ssh-key":                "Öffentlicher SSH-Schlüssel oder Datei mit Schlüsseln (leer zum Beenden)",
+
+	"tui.title":                "Void Linux Installer",
+	"tui.inspecting":           "Bitte warten, das System wird untersucht...",
+	"tui.review-install":       "Überprüfen und installieren",
+	"tui.quit":                 "Beenden",
+	"tui.quit-question":        "Den Installer verlassen, ohne etwas zu installieren?",
+	"tui.problems":             "Die Konfiguration hat Probleme, die zuerst behoben werden müssen:",
+	"tui.review":               "Überprüfung",
+	"tui.help-review":          "Enter: weiter  Esc: zurück zum Menü",
+	"tui.confirm":              "Installation bestätigen",
+	"tui.confirm-question":     "Das System wird nun wie eingerichtet installiert.\n\nDaten auf dem Ziel können verloren gehen.  Mit der Installation fortfahren?",
+	"tui.help-menu":            "Enter: auswählen  Esc: zurück  Tippen zum Suchen",
+	"tui.search":               "Suche: %s",
+	"tui.no-match":             "Keine Treffer",
+	"tui.help-input":           "Enter: übernehmen  Esc: zurück",
+	"tui.help-message":         "Enter: weiter",
+	"tui.help-yes-no":          "j: ja  n/Esc: nein",
+	"tui.help-checklist":       "Leertaste: ankreuzen  Enter: übernehmen  Esc: zurück",
+	"tui.yes":                  "ja",
+	"tui.no":                   "nein",
+	"tui.done":                 "Fertig",
+	"tui.section-disk":         "Festplatte",
+	"tui.section-hostname":     "Rechnername",
+	"tui.section-timezone":     "Zeitzone",
+	"tui.section-locale":       "Gebietsschema",
+	"tui.section-keyboard":     "Tastatur",
+	"tui.section-console":      "Konsole",
+	"tui.section-bootloader":   "Bootloader",
+	"tui.section-packages":     "Pakete",
+	"tui.section-network":      "Netzwerk",
+	"tui.section-services":     "Dienste",
+	"tui.section-root":         "root-Konto",
+	"tui.section-users":        "Benutzer",
+	"tui.section-privileges":   "Berechtigungen",
+	"tui.hostname-label":       "Name des Systems, auch voll qualifiziert:",
+	"tui.domain-label":         "Domäne des Systems, falls vorhanden:",
+	"tui.timezone-label":       "Zeitzone, etwa Europe/Berlin:",
+	"tui.timezone-other":       "Andere",
+	"tui.timezone-region":      "Zeitzone: Region",
+	"tui.timezone-in":          "Zeitzone: %s",
+	"tui.locale-label":         "glibc-Gebietsschema, etwa de_DE.UTF-8:",
+	"tui.keyboard-label":       "Tastaturbelegung der Konsole, etwa de:",
+	"tui.defaults":             "Standardwerte",
+	"tui.customized":           "angepasst",
+	"tui.default":              "Standard",
+	"tui.clock":                "Hardware-Uhr",
+	"tui.font":                 "Schrift",
+	"tui.font-map":             "Konsolen-Map",
+	"tui.font-unimap":          "Konsolen-Unimap",
+	"tui.font-label":           "Konsolenschrift, leer für die Voreinstellung:",
+	"tui.font-map-label":       "Konsolen-Map, leer für keine:",
+	"tui.font-unimap-label":    "Unicode-Map, leer für keine:",
+	"tui.ttys":                 "TTYs",
+	"tui.ttys-label":           "Anzahl der TTYs, leer für die Voreinstellung:",
+	"tui.rc-vars":              "rc.conf-Variablen",
+	"tui.rc-var-label":         "%s, leer zum Entfernen:",
+	"tui.rc-var-add":           "Variable hinzufügen",
+	"tui.rc-var-new":           "NAME=Wert:",
+	"tui.rc-var-format":        "Variablen müssen als NAME=Wert angegeben werden",
+	"tui.not-installed":        "nicht installiert",
+	"tui.grub-title":           "GRUB installieren auf",
+	"tui.grub-device":          "Gerät eingeben",
+	"tui.no-grub":              "GRUB nicht installieren",
+	"tui.grub-device-label":    "Gerät, auf das GRUB installiert wird:",
+	"tui.grub-graphical":       "Das grafische GRUB-Menü verwenden?",
+	"tui.packages-label":       "Zusätzliche Pakete, durch Leerzeichen getrennt:",
+	"tui.manager":              "Verwaltung",
+	"tui.manager-title":        "Netzwerkverwaltung",
+	"tui.interface":            "Schnittstelle %s",
+	"tui.other-interface":      "Andere Schnittstelle",
+	"tui.interface-label":      "Name der Schnittstelle:",
+	"tui.dns":                  "DNS-Server",
+	"tui.dns-label":            "Nameserver, durch Leerzeichen getrennt, leer für DHCP:",
+	"tui.wifi":                 "WLAN-Netze",
+	"tui.not-configured":       "nicht eingerichtet",
+	"tui.static":               "Feste Adresse",
+	"tui.unconfigure":          "Nicht eingerichtet",
+	"tui.addresses-label":      "Adressen in CIDR-Notation, höchstens eine IPv4 und eine IPv6:",
+	"tui.gateway-label":        "IPv4-Gateway, leer für keines:",
+	"tui.wifi-open":            "offen",
+	"tui.wifi-protected":       "geschützt",
+	"tui.wifi-title":           "WLAN",
+	"tui.wifi-forget":          "Das Netz %s vergessen?",
+	"tui.wifi-add":             "Netz hinzufügen",
+	"tui.ssid-label":           "Name des Netzes (SSID):",
+	"tui.psk-label":            "Passphrase, leer für ein offenes Netz:",
+	"tui.services-enable":      "Zusätzlich zu aktivierende Dienste, durch Leerzeichen getrennt:",
+	"tui.services-disable":     "Zu deaktivierende Dienste, durch Leerzeichen getrennt:",
+	"tui.locked":               "gesperrt",
+	"tui.password-set":         "Passwort gesetzt",
+	"tui.no-password":          "kein Passwort",
+	"tui.not-set":              "nicht gesetzt",
+	"tui.set":                  "gesetzt",
+	"tui.password":             "Passwort",
+	"tui.root-lock-wheel":      "root kann erst gesperrt werden, wenn ein Benutzer in der Gruppe wheel ist.",
+	"tui.lock-root":            "root sperren",
+	"tui.shell":                "Shell",
+	"tui.shell-label":          "Login-Shell, leer für die Voreinstellung:",
+	"tui.password-label":       "Passwort, ! zum Sperren des Kontos, oder ein unverändert zu verwendender Hash:",
+	"tui.password-weak":        "Dieses Passwort ist schwach: %s.\n\nTrotzdem verwenden?",
+	"tui.password-again":       "Passwort wiederholen:",
+	"tui.password-mismatch":    "Die Passwörter stimmen nicht überein.",
+	"tui.none":                 "keine",
+	"tui.user-add":             "Benutzer hinzufügen",
+	"tui.user-new":             "Neuer Benutzer",
+	"tui.user":                 "Benutzer",
+	"tui.user-named":           "Benutzer %s",
+	"tui.username":             "Benutzername",
+	"tui.username-label":       "Benutzername:",
+	"tui.gecos":                "Vollständiger Name",
+	"tui.gecos-label":          "Vollständiger Name:",
+	"tui.groups":               "Gruppen",
+	"tui.groups-label":         "Gruppen, durch Kommas getrennt:",
+	"tui.user-delete":          "Diesen Benutzer löschen",
+	"tui.user-delete-question": "Den Benutzer %s löschen?",
+	"tui.tool":                 "Werkzeug",
+	"tui.wheel-password":       "wheel braucht ein Passwort",
+	"tui.disk-mounted":         "bereits eingehängt",
+	"tui.filesystems":          "%s Dateisysteme",
+	"tui.disk-item":            "%s, %s Partitionen",
+	"tui.disk-use-mounted":     "Die bereits im Ziel eingehängten Dateisysteme verwenden",
+	"tui.disk-title":           "Festplatte für die Installation",
+	"tui.layout-title":         "Aufteilung von %s",
+	"tui.layout-plan":          "%s wird so aufgeteilt:",
+	"tui.encryption":           "Verschlüsselung",
+	"tui.passphrase-label":     "Passphrase zum Entsperren von %s:",
+	"tui.passphrase-weak":      "Diese Passphrase ist schwach: %s.\n\nTrotzdem verwenden?",
+	"tui.passphrase-again":     "Passphrase wiederholen:",
+	"tui.passphrase-mismatch":  "Die Passphrasen stimmen nicht überein.",
+	"tui.erased":               "Dies wird gelöscht:",
+	"tui.erase-title":          "%s löschen",
+	"tui.erase":                "%s löschen?",
+	"tui.partitions":           "Partitionen",
+	"tui.cfdisk":               "cfdisk schreibt seine Änderungen an %s, sobald es dazu aufgefordert wird, noch bevor die Installation bestätigt ist, und was auf den geänderten Partitionen war, geht verloren.",
+	"tui.partitions-title":     "Partitionen von %s",
+	"tui.not-used":             "nicht verwendet",
+	"tui.formatted":            "formatiert",
+	"tui.mount-label":          "Einhängepunkt, swap, oder leer, um sie nicht zu verwenden:",
+	"tui.mount-absolute":       "ein Einhängepunkt beginnt mit /",
+	"tui.filesystem-title":     "Dateisystem von %s",
+	"tui.format":               "%s formatieren und den Inhalt löschen?",
+	"tui.installing":           "Void Linux wird installiert",
+	"tui.help-progress":        "Pfeile/Bild↑/Bild↓: blättern  Ende: folgen  Strg-C: abbrechen",
+	"tui.help-finished":        "Pfeile/Bild↑/Bild↓: blättern  Enter: beenden",
+	"tui.cancelling":           "Die Installation wird abgebrochen...",
+	"tui.preparing":            "Vorbereitung",
+	"tui.failed":               "Die Installation ist fehlgeschlagen, siehe das Protokoll unten",
+	"tui.complete":             "Die Installation ist abgeschlossen",
+
+	"layout.ext4":   "ein einzelnes ext4-Dateisystem",
+	"layout.btrfs":  "btrfs mit Subvolumes für / und /home, komprimiert",
+	"layout.luks":   "ext4 auf LVM in einer verschlüsselten Partition, mit unverschlüsseltem /boot",
+	"layout.custom": "Die Festplatte selbst mit cfdisk partitionieren",
+
+	"group.wheel":   "kann mit sudo oder doas root werden",
+	"group.audio":   "kann Audiogeräte direkt verwenden",
+	"group.video":   "kann Grafikgeräte und GPU-Beschleunigung verwenden",
+	"group.network": "kann Netzwerkverbindungen verwalten",
+	"group.plugdev": "kann Wechseldatenträger einhängen",
+	"group.input":   "kann Eingabegeräte direkt lesen",
+}
@@ -0,0 +1,337 @@
+package i18n
+
+// english is the text of every message, and what the other
+// catalogues translate.
+var english = Catalogue{
+	// The installer, as it reports on the installation.
+	"installer.step":                    "Step %s of %s: %s",
+	"installer.resuming":                "Resuming installation after step %q",
+	"installer.completed":               "Installation to %s completed",
+	"installer.cancelled":               "Installation cancelled during step %q",
+	"installer.failed":                  "Installation failed during step %q: %s",
+	"installer.no-steps":                "  No steps were completed, %s may still contain partial files",
+	"installer.completed-steps":         "  Completed steps: %s",
+	"installer.partial":                 "  %s contains a partially installed system",
+	"installer.rolled-back":             "  The configuration of the target has been rolled back:",
+	"installer.cleanup-failed":          "  Cleanup did not succeed, %s must be done by hand",
+	"installer.formatting":              "Formatting %s as %s",
+	"installer.erasing":                 "Erasing %s and laying it out as %s",
+	"installer.dry-run":                 "Dry run, nothing is changed.  These commands would be run:",
+	"installer.opening":                 "Opening %s",
+	"installer.initramfs":               "Configuring the initramfs to unlock the root filesystem",
+	"installer.initramfs-done":          "  The initramfs has been regenerated",
+	"installer.keys":                    "Installing keys",
+	"installer.packages":                "Installing additional packages",
+	"installer.configuring":             "Configuring %s",
+	"installer.configured":              "  %s has been configured",
+	"installer.names":                   "Configuring network names",
+	"installer.localtime":               "  /etc/localtime points to %s",
+	"installer.xkb-guess":               "  %s has no known X11 layout, guessing",
+	"installer.users":                   "Adding user accounts",
+	"installer.users-added":             "  User accounts added",
+	"installer.user-exists":             "  %s already exists",
+	"installer.locked":                  "  %s has been locked",
+	"installer.no-home":                 "  %s has no home directory, not installing SSH keys",
+	"installer.ssh-keys":                "  Installed %s SSH key(s) for %s",
+	"installer.no-group":                "  Group %s does not exist, skipping it",
+	"installer.group-created":           "  Created group %s",
+	"installer.root":                    "Configuring the root account",
+	"installer.root-not-locked":         "  Not locking root, no user is in the wheel group",
+	"installer.root-shell":              "  root's shell is %s",
+	"installer.network":                 "Configuring the network",
+	"installer.services":                "Enabling services",
+	"installer.service-enabled":         "  %s enabled in %s",
+	"installer.service-down":            "  %s enabled in %s, not started",
+	"installer.service-already-enabled": "  %s is already enabled in %s",
+	"installer.service-disabled":        "  %s disabled in %s",
+	"installer.files":                   "Writing additional files",
+
+	// The prompt frontend.
+	"prompt.yes":                    "yes",
+	"prompt.no":                     "no",
+	"prompt.welcome":                "Welcome to the Void Linux Installer",
+	"prompt.inspecting":             "Please wait while the installer inspects your system...",
+	"prompt.defaults-help":          "Press enter to keep the value shown in brackets, or enter - to clear it.",
+	"prompt.choice":                 "Choice",
+	"prompt.count":                  "%s in the list:",
+	"prompt.count-titled":           "%s, %s in the list:",
+	"prompt.echo":                   "Chosen: %s",
+	"prompt.echo-empty":             "Nothing chosen",
+	"prompt.checked":                "checked",
+	"prompt.unchecked":              "not checked",
+	"prompt.keep":                   "Keep them?",
+	"prompt.use-anyway":             "Use it anyway?",
+	"prompt.proceed-question":       "Do you wish to proceed with installation?",
+	"prompt.proceed":                "Proceed (yes/no)",
+	"prompt.review":                 "Please review your configuration:",
+	"prompt.review-section":         "Section to change (blank to continue)",
+	"prompt.review-problems":        "Please fix the problems marked with ! first",
+	"prompt.review-choose":          "Choose a section from 1 to %s",
+	"prompt.section-disk":           "Disk",
+	"prompt.section-hostname":       "Hostname",
+	"prompt.section-timezone":       "Time zone",
+	"prompt.section-locale":         "Locale",
+	"prompt.section-bootloader":     "Bootloader",
+	"prompt.section-keyboard":       "Keyboard",
+	"prompt.section-packages":       "Packages",
+	"prompt.section-network":        "Network",
+	"prompt.section-console":        "Console",
+	"prompt.section-users":          "Users",
+	"prompt.section-root-password":  "Root password",
+	"prompt.section-root-shell":     "Root shell",
+	"prompt.summary-layout":         "%s erased, %s",
+	"prompt.summary-filesystem":     "%s on %s",
+	"prompt.summary-mounted":        "filesystems already mounted",
+	"prompt.summary-no-grub":        "not installed",
+	"prompt.summary-grub":           "GRUB on %s",
+	"prompt.summary-grub-graphical": "GRUB on %s, graphical",
+	"prompt.summary-wifi":           "wifi %s",
+	"prompt.summary-clock":          "clock %s",
+	"prompt.summary-font":           "font %s",
+	"prompt.summary-ttys":           "%s ttys",
+	"prompt.summary-rc-vars":        "%s rc.conf variables",
+	"prompt.summary-defaults":       "defaults",
+	"prompt.summary-locked":         "locked",
+	"prompt.summary-not-set":        "not set",
+	"prompt.summary-set":            "set",
+	"prompt.console-skip":           "Configure advanced console settings?",
+	"prompt.disks":                  "Disks:",
+	"prompt.disk-live":              "(%s is what the installer is running from and can't be installed to)",
+	"prompt.disk-mounted":           "Install to filesystems already mounted on the target",
+	"prompt.disk":                   "Disk to install to (blank to keep the current choice)",
+	"prompt.disk-choose":            "Choose a disk from 1 to %s, or m",
+	"prompt.layouts":                "Layouts:",
+	"prompt.layout":                 "Layout",
+	"prompt.layout-choose":          "Choose a layout from 1 to %s",
+	"prompt.layout-plan":            "%s will be laid out as:",
+	"prompt.passphrase":             "Passphrase to unlock %s",
+	"prompt.passphrase-again":       "Passphrase again",
+	"prompt.passphrase-weak":        "This passphrase is weak: %s",
+	"prompt.passphrase-mismatch":    "The passphrases don't match",
+	"prompt.erased":                 "This will be erased:",
+	"prompt.erase":                  "Erase %s?",
+	"prompt.these-partitions":       "these partitions",
+	"prompt.cfdisk":                 "cfdisk writes its changes to %s as soon as it is told to, before the installation is confirmed, and what was on the partitions it changes is lost.",
+	"prompt.mount-point":            "Mount point, or swap (blank to leave it out)",
+	"prompt.mount-point-absolute":   "a mount point starts with /",
+	"prompt.filesystem-type":        "Filesystem type",
+	"prompt.format":                 "Format %s?",
+	"prompt.hostname":               "System Hostname",
+	"prompt.domain":                 "Domain (blank for none)",
+	"prompt.timezone":               "Enter your timezone",
+	"prompt.timezone-browse":        "Enter your timezone (? to browse, /name to search)",
+	"prompt.timezone-regions":       "Regions:",
+	"prompt.timezone-other":         "Other",
+	"prompt.timezone-zones":         "Zones:",
+	"prompt.timezone-none":          "No matching timezones",
+	"prompt.timezone-matching":      "Matching timezones:",
+	"prompt.locale":                 "Please enter your GLibC Locale",
+	"prompt.grub":                   "Use GRUB?",
+	"prompt.grub-graphical":         "Use graphical GRUB?",
+	"prompt.grub-disk":              "Install GRUB to",
+	"prompt.grub-no-disk":           "a disk is needed to install GRUB to",
+	"prompt.keyboard":               "Please enter your keyboard layout (? to list, /name to search)",
+	"prompt.xorg":                   "Install a graphical environment (xorg)?",
+	"prompt.packages":               "Additional packages (space separated)",
+	"prompt.network-manager":        "Network manager, dhcpcd or NetworkManager",
+	"prompt.interfaces-configured":  "Configured interfaces:",
+	"prompt.interfaces":             "Network interfaces:",
+	"prompt.interface":              "Interface to configure (blank for all with DHCP)",
+	"prompt.address":                "Address in CIDR notation (blank for DHCP)",
+	"prompt.gateway":                "Gateway (blank for none)",
+	"prompt.dns":                    "DNS servers (space separated, blank for DHCP)",
+	"prompt.wifi-configured":        "Wifi networks:",
+	"prompt.wifi":                   "Wifi network to join (blank to finish)",
+	"prompt.wifi-passphrase":        "Passphrase (blank if open)",
+	"prompt.clock":                  "Hardware clock, UTC or localtime (blank for UTC)",
+	"prompt.font":                   "Console font (blank for the default)",
+	"prompt.font-map":               "Console map (blank for none)",
+	"prompt.font-unimap":            "Console unimap (blank for none)",
+	"prompt.ttys":                   "Number of TTYs (blank for the default)",
+	"prompt.rc-vars":                "Additional rc.conf variables:",
+	"prompt.rc-var":                 "Additional rc.conf variable (NAME=value, NAME= to remove, blank to finish)",
+	"prompt.rc-var-format":          "Variables must be given as NAME=value",
+	"prompt.users":                  "Users:",
+	"prompt.users-edit":             "Number to edit, a to add, d and a number to delete, blank when done",
+	"prompt.users-choose":           "Choose a user from 1 to %s",
+	"prompt.user-add":               "Do you wish to add a user?",
+	"prompt.user-delete":            "Delete the user %s?",
+	"prompt.username":               "Username",
+	"prompt.gecos":                  "Name for the user",
+	"prompt.shell":                  "Login shell (blank for the default)",
+	"prompt.root-shell":             "Shell for root (blank for the default)",
+	"prompt.password":               "Password for %s (! to lock, or paste a hash)",
+	"prompt.password-keep":          "Password for %s (! to lock, or paste a hash) (blank to keep)",
+	"prompt.password-again":         "Password again",
+	"prompt.password-none":          "Leave %s without a password?",
+	"prompt.password-weak":          "This password is weak: %s",
+	"prompt.password-mismatch":      "The passwords don't match",
+	"prompt.groups":                 "Groups:",
+	"prompt.groups-toggle":          "Numbers to toggle, +name to add another group, blank when done",
+	"prompt.groups-choose":          "%s: choose a group from 1 to %s",
+	"prompt.ssh-keys":               "SSH keys:",
+	"prompt.ssh-key":                "SSH public key, or file of keys (blank to finish)",
+
+	// The full screen frontend.
+	"tui.title":                "Void Linux Installer",
+	"tui.inspecting":           "Please wait while the installer inspects your system...",
+	"tui.review-install":       "Review and install",
+	"tui.quit":                 "Quit",
+	"tui.quit-question":        "Leave the installer without installing anything?",
+	"tui.problems":             "The configuration has problems that must be fixed first:",
+	"tui.review":               "Review",
+	"tui.help-review":          "Enter: continue  Esc: back to the menu",
+	"tui.confirm":              "Confirm installation",
+	"tui.confirm-question":     "The system is about to be installed as configured.\n\nData on the target may be lost.  Proceed with the installation?",
+	"tui.help-menu":            "Enter: select  Esc: back  Type to search",
+	"tui.search":               "Search: %s",
+	"tui.no-match":             "Nothing matches",
+	"tui.help-input":           "Enter: accept  Esc: back",
+	"tui.help-message":         "Enter: continue",
+	"tui.help-yes-no":          "y: yes  n/Esc: no",
+	"tui.help-checklist":       "Space: tick  Enter: accept  Esc: back",
+	"tui.yes":                  "yes",
+	"tui.no":                   "no",
+	"tui.done":                 "Done",
+	"tui.section-disk":         "Disk",
+	"tui.section-hostname":     "Hostname",
+	"tui.section-timezone":     "Time zone",
+	"tui.section-locale":       "Locale",
+	"tui.section-keyboard":     "Keyboard",
+	"tui.section-console":      "Console",
+	"tui.section-bootloader":   "Bootloader",
+	"tui.section-packages":     "Packages",
+	"tui.section-network":      "Network",
+	"tui.section-services":     "Services",
+	"tui.section-root":         "Root account",
+	"tui.section-users":        "Users",
+	"tui.section-privileges":   "Privileges",
+	"tui.hostname-label":       "Name of the system, which may be fully qualified:",
+	"tui.domain-label":         "Domain of the system, if any:",
+	"tui.timezone-label":       "Time zone, such as Europe/Berlin:",
+	"tui.timezone-other":       "Other",
+	"tui.timezone-region":      "Time zone: region",
+	"tui.timezone-in":          "Time zone: %s",
+	"tui.locale-label":         "glibc locale, such as en_US.UTF-8:",
+	"tui.keyboard-label":       "Console keymap, such as us:",
+	"tui.defaults":             "defaults",
+	"tui.customized":           "customized",
+	"tui.default":              "default",
+	"tui.clock":                "Hardware clock",
+	"tui.font":                 "Font",
+	"tui.font-map":             "Font map",
+	"tui.font-unimap":          "Font unimap",
+	"tui.font-label":           "Console font, blank for the default:",
+	"tui.font-map-label":       "Console map, blank for none:",
+	"tui.font-unimap-label":    "Unicode map, blank for none:",
+	"tui.ttys":                 "TTYs",
+	"tui.ttys-label":           "Number of TTYs, blank for the default:",
+	"tui.rc-vars":              "rc.conf variables",
+	"tui.rc-var-label":         "%s, blank to remove:",
+	"tui.rc-var-add":           "Add a variable",
+	"tui.rc-var-new":           "NAME=value:",
+	"tui.rc-var-format":        "variables must be given as NAME=value",
+	"tui.not-installed":        "not installed",
+	"tui.grub-title":           "Install GRUB to",
+	"tui.grub-device":          "Enter a device",
+	"tui.no-grub":              "Don't install GRUB",
+	"tui.grub-device-label":    "Device to install GRUB to:",
+	"tui.grub-graphical":       "Use the graphical GRUB menu?",
+	"tui.packages-label":       "Additional packages, space separated:",
+	"tui.manager":              "Manager",
+	"tui.manager-title":        "Network manager",
+	"tui.interface":            "Interface %s",
+	"tui.other-interface":      "Other interface",
+	"tui.interface-label":      "Interface name:",
+	"tui.dns":                  "DNS servers",
+	"tui.dns-label":            "Name servers, space separated, blank to use DHCP:",
+	"tui.wifi":                 "Wifi networks",
+	"tui.not-configured":       "not configured",
+	"tui.static":               "Static address",
+	"tui.unconfigure":          "Not configured",
+	"tui.addresses-label":      "Addresses in CIDR notation, one IPv4 and one IPv6 at most:",
+	"tui.gateway-label":        "IPv4 gateway, blank for none:",
+	"tui.wifi-open":            "open",
+	"tui.wifi-protected":       "protected",
+	"tui.wifi-title":           "Wifi",
+	"tui.wifi-forget":          "Forget the network %s?",
+	"tui.wifi-add":             "Add a network",
+	"tui.ssid-label":           "Network name (SSID):",
+	"tui.psk-label":            "Passphrase, blank for an open network:",
+	"tui.services-enable":      "Services to enable in addition to the defaults, space separated:",
+	"tui.services-disable":     "Services to disable, space separated:",
+	"tui.locked":               "locked",
+	"tui.password-set":         "password set",
+	"tui.no-password":          "no password",
+	"tui.not-set":              "not set",
+	"tui.set":                  "set",
+	"tui.password":             "Password",
+	"tui.root-lock-wheel":      "Root can only be locked once a user is in the wheel group.",
+	"tui.lock-root":            "Lock root",
+	"tui.shell":                "Shell",
+	"tui.shell-label":          "Login shell, blank for the default:",
+	"tui.password-label":       "Password, ! to lock the account, or a hash to use as is:",
+	"tui.password-weak":        "This password is weak: %s.\n\nUse it anyway?",
+	"tui.password-again":       "Password again:",
+	"tui.password-mismatch":    "The passwords don't match.",
+	"tui.none":                 "none",
+	"tui.user-add":             "Add a user",
+	"tui.user-new":             "New user",
+	"tui.user":                 "User",
+	"tui.user-named":           "User %s",
+	"tui.username":             "Username",
+	"tui.username-label":       "Username:",
+	"tui.gecos":                "Full name",
+	"tui.gecos-label":          "Full name:",
+	"tui.groups":               "Groups",
+	"tui.groups-label":         "Groups, comma separated:",
+	"tui.user-delete":          "Delete this user",
+	"tui.user-delete-question": "Delete the user %s?",
+	"tui.tool":                 "Tool",
+	"tui.wheel-password":       "Wheel needs password",
+	"tui.disk-mounted":         "already mounted",
+	"tui.filesystems":          "%s filesystems",
+	"tui.disk-item":            "%s, %s partitions",
+	"tui.disk-use-mounted":     "Use the filesystems already mounted on the target",
+	"tui.disk-title":           "Disk to install to",
+	"tui.layout-title":         "Layout of %s",
+	"tui.layout-plan":          "%s will be laid out as:",
+	"tui.encryption":           "Encryption",
+	"tui.passphrase-label":     "Passphrase to unlock %s:",
+	"tui.passphrase-weak":      "This passphrase is weak: %s.\n\nUse it anyway?",
+	"tui.passphrase-again":     "Passphrase again:",
+	"tui.passphrase-mismatch":  "The passphrases don't match.",
+	"tui.erased":               "This will be erased:",
+	"tui.erase-title":          "Erase %s",
+	"tui.erase":                "Erase %s?",
+	"tui.partitions":           "partitions",
+	"tui.cfdisk":               "cfdisk writes its changes to %s as soon as it is told to, before the installation is confirmed, and what was on the partitions it changes is lost.",
+	"tui.partitions-title":     "Partitions of %s",
+	"tui.not-used":             "not used",
+	"tui.formatted":            "formatted",
+	"tui.mount-label":          "Mount point, swap, or blank to leave it out:",
+	"tui.mount-absolute":       "a mount point starts with /",
+	"tui.filesystem-title":     "Filesystem of %s",
+	"tui.format":               "Format %s, erasing what is on it?",
+	"tui.installing":           "Installing Void Linux",
+	"tui.help-progress":        "Arrows/PgUp/PgDn: scroll  End: follow  Ctrl-C: cancel",
+	"tui.help-finished":        "Arrows/PgUp/PgDn: scroll  Enter: exit",
+	"tui.cancelling":           "Cancelling the installation...",
+	"tui.preparing":            "Preparing",
+	"tui.failed":               "The installation failed, see the log below",
+	"tui.complete":             "The installation is complete",
+
+	// The guided disk layouts.
+	"layout.ext4":   "a single ext4 filesystem",
+	"layout.btrfs":  "btrfs with subvolumes for / and /home, compressed",
+	"layout.luks":   "ext4 on LVM in an encrypted partition, with an unencrypted /boot",
+	"layout.custom": "Partition the disk yourself with cfdisk",
+
+	// The groups offered to users.
+	"group.wheel":   "can become root with sudo or doas",
+	"group.audio":   "can use sound devices directly",
+	"group.video":   "can use video devices and GPU acceleration",
+	"group.network": "can manage network connections",
+	"group.plugdev": "can mount removable devices",
+	"group.input":   "can read input devices directly",
+}
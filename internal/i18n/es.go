@@ -0,0 +1,330 @@
+package i18n
+
+var spanish = Catalogue{
+	"installer.step":                    "Paso %s de %s: %s",
+	"installer.resuming":                "Reanudando la instalación después del paso %q",
+	"installer.completed":               "Instalación en %s completada",
+	"installer.cancelled":               "Instalación cancelada durante el paso %q",
+	"installer.failed":                  "La instalación falló durante el paso %q: %s",
+	"installer.no-steps":                "  No se completó ningún paso, %s puede contener aún archivos parciales",
+	"installer.completed-steps":         "  Pasos completados: %s",
+	"installer.partial":                 "  %s contiene un sistema instalado parcialmente",
+	"installer.rolled-back":             "  Se ha revertido la configuración del destino:",
+	"installer.cleanup-failed":          "  La limpieza no tuvo éxito, %s debe hacerse a mano",
+	"installer.formatting":              "Formateando %s como %s",
+	"installer.erasing":                 "Borrando %s y distribuyéndolo como %s",
+	"installer.dry-run":                 "Simulación, no se cambia nada.  Se ejecutarían estas órdenes:",
+	"installer.opening":                 "Abriendo %s",
+	"installer.initramfs":               "Configurando el initramfs para desbloquear el sistema de archivos raíz",
+	"installer.initramfs-done":          "  Se ha regenerado el initramfs",
+	"installer.keys":                    "Instalando las claves",
+	"installer.packages":                "Instalando paquetes adicionales",
+	"installer.configuring":             "Configurando %s",
+	"installer.configured":              "  %s se ha configurado",
+	"installer.names":                   "Configurando los nombres de red",
+	"installer.localtime":               "  /etc/localtime apunta a %s",
+	"installer.xkb-guess":               "  %s no tiene una distribución de X11 conocida, se adivinará",
+	"installer.users":                   "Añadiendo cuentas de usuario",
+	"installer.users-added":             "  Cuentas de usuario añadidas",
+	"installer.user-exists":             "  %s ya existe",
+	"installer.locked":                  "  %s se ha bloqueado",
+	"installer.no-home":                 "  %s no tiene directorio personal, no se instalan claves SSH",
+	"installer.ssh-keys":                "  Se instalaron %s claves SSH para %s",
+	"installer.no-group":                "  El grupo %s no existe, se omite",
+	"installer.group-created":           "  Se creó el grupo %s",
+	"installer.root":                    "Configurando la cuenta root",
+	"installer.root-not-locked":         "  No se bloquea root, ningún usuario está en el grupo wheel",
+	"installer.root-shell":              "  El intérprete de root es %s",
+	"installer.network":                 "Configurando la red",
+	"installer.services":                "Activando servicios",
+	"installer.service-enabled":         "  %s activado en %s",
+	"installer.service-down":            "  %s activado en %s, sin iniciar",
+	"installer.service-already-enabled": "  %s ya está activado en %s",
+	"installer.service-disabled":        "  %s desactivado en %s",
+	"installer.files":                   "Escribiendo archivos adicionales",
+
+	"prompt.yes":                    "sí",
+	"prompt.no":                     "no",
+	"prompt.welcome":                "Bienvenido al instalador de Void Linux",
+	"prompt.inspecting":             "Espere mientras el instalador examina su sistema...",
+	"prompt.defaults-help":          "Pulse Intro para mantener el valor entre corchetes, o escriba - para borrarlo.",
+	"prompt.choice":                 "Opción",
+	"prompt.count":                  "%s en la lista:",
+	"prompt.count-titled":           "%s, %s en la lista:",
+	"prompt.echo":                   "Elegido: %s",
+	"prompt.echo-empty":             "Nada elegido",
+	"prompt.checked":                "marcado",
+	"prompt.unchecked":              "sin marcar",
+	"prompt.keep":                   "¿Mantenerlos?",
+	"prompt.use-anyway":             "¿Usarla de todos modos?",
+	"prompt.proceed-question":       "¿Desea continuar con la instalación?",
+	"prompt.proceed":                "Continuar (sí/no)",
+	"prompt.review":                 "Revise su configuración:",
+	"prompt.review-section":         "Sección a cambiar (vacío para continuar)",
+	"prompt.review-problems":        "Corrija primero los problemas marcados con !",
+	"prompt.review-choose":          "Elija una sección del 1 al %s",
+	"prompt.section-disk":           "Disco",
+	"prompt.section-hostname":       "Nombre del equipo",
+	"prompt.section-timezone":       "Zona horaria",
+	"prompt.section-locale":         "Configuración regional",
+	"prompt.section-bootloader":     "Cargador de arranque",
+	"prompt.section-keyboard":       "Teclado",
+	"prompt.section-packages":       "Paquetes",
+	"prompt.section-network":        "Red",
+	"prompt.section-console":        "Consola",
+	"prompt.section-users":          "Usuarios",
+	"prompt.section-root-password":  "Contraseña de root",
+	"prompt.section-root-shell":     "Intérprete de root",
+	"prompt.summary-layout":         "%s borrado, %s",
+	"prompt.summary-filesystem":     "%s en %s",
+	"prompt.summary-mounted":        "sistemas de archivos ya montados",
+	"prompt.summary-no-grub":        "no instalado",
+	"prompt.summary-grub":           "GRUB en %s",
+	"prompt.summary-grub-graphical": "GRUB en %s, gráfico",
+	"prompt.summary-wifi":           "wifi %s",
+	"prompt.summary-clock":          "reloj %s",
+	"prompt.summary-font":           "fuente %s",
+	"prompt.summary-ttys":           "%s ttys",
+	"prompt.summary-rc-vars":        "%s variables de rc.conf",
+	"prompt.summary-defaults":       "valores predeterminados",
+	"prompt.summary-locked":         "bloqueada",
+	"prompt.summary-not-set":        "sin definir",
+	"prompt.summary-set":            "definida",
+	"prompt.console-skip":           "¿Configurar opciones avanzadas de la consola?",
+	"prompt.disks":                  "Discos:",
+	"prompt.disk-live":              "(%s es desde donde se ejecuta el instalador y no se puede instalar en él)",
+	"prompt.disk-mounted":           "Instalar en sistemas de archivos ya montados en el destino",
+	"prompt.disk":                   "Disco donde instalar (vacío para mantener la elección actual)",
+	"prompt.disk-choose":            "Elija un disco del 1 al %s, o m",
+	"prompt.layouts":                "Distribuciones:",
+	"prompt.layout":                 "Distribución",
+	"prompt.layout-choose":          "Elija una distribución del 1 al %s",
+	"prompt.layout-plan":            "%s quedará distribuido así:",
+	"prompt.passphrase":             "Frase de contraseña para desbloquear %s",
+	"prompt.passphrase-again":       "Repita la frase de contraseña",
+	"prompt.passphrase-weak":        "Esta frase de contraseña es débil: %s",
+	"prompt.passphrase-mismatch":    "Las frases de contraseña no coinciden",
+	"prompt.erased":                 "Se borrará lo siguiente:",
+	"prompt.erase":                  "¿Borrar %s?",
+	"prompt.these-partitions":       "estas particiones",
+	"prompt.cfdisk":                 "cfdisk escribe sus cambios en %s en cuanto se le indica, antes de que se confirme la instalación, y se pierde lo que hubiera en las particiones que cambie.",
+	"prompt.mount-point":            "Punto de montaje, o swap (vacío para no usarla)",
+	"prompt.mount-point-absolute":   "un punto de montaje empieza por /",
+	"prompt.filesystem-type":        "Tipo de sistema de archivos",
+	"prompt.format":                 "¿Formatear %s?",
+	"prompt.hostname":               "Nombre del equipo",
+	"prompt.domain":                 "Dominio (vacío para ninguno)",
+	"prompt.timezone":               "Introduzca su zona horaria",
+	"prompt.timezone-browse":        "Introduzca su zona horaria (? para explorar, /nombre para buscar)",
+	"prompt.timezone-regions":       "Regiones:",
+	"prompt.timezone-other":         "Otra",
+	"prompt.timezone-zones":         "Zonas:",
+	"prompt.timezone-none":          "No hay zonas horarias que coincidan",
+	"prompt.timezone-matching":      "Zonas horarias que coinciden:",
+	"prompt.locale":                 "Introduzca su configuración regional de glibc",
+	"prompt.grub":                   "¿Usar GRUB?",
+	"prompt.grub-graphical":         "¿Usar GRUB gráfico?",
+	"prompt.grub-disk":              "Instalar GRUB en",
+	"prompt.grub-no-disk":           "hace falta un disco donde instalar GRUB",
+	"prompt.keyboard":               "Introduzca la distribución de su teclado (? para listar, /nombre para buscar)",
+	"prompt.xorg":                   "¿Instalar un entorno gráfico (xorg)?",
+	"prompt.packages":               "Paquetes adicionales (separados por espacios)",
+	"prompt.network-manager":        "Gestor de red, dhcpcd o NetworkManager",
+	"prompt.interfaces-configured":  "Interfaces configuradas:",
+	"prompt.interfaces":             "Interfaces de red:",
+	"prompt.interface":              "Interfaz a configurar (vacío para DHCP en todas)",
+	"prompt.address":                "Dirección en notación CIDR (vacío para DHCP)",
+	"prompt.gateway":                "Puerta de enlace (vacío para ninguna)",
+	"prompt.dns":                    "Servidores DNS (separados por espacios, vacío para DHCP)",
+	"prompt.wifi-configured":        "Redes wifi:",
+	"prompt.wifi":                   "Red wifi a la que unirse (vacío para terminar)",
+	"prompt.wifi-passphrase":        "Frase de contraseña (vacío si es abierta)",
+	"prompt.clock":                  "Reloj de hardware, UTC o localtime (vacío para UTC)",
+	"prompt.font":                   "Fuente de la consola (vacío para la predeterminada)",
+	"prompt.font-map":               "Mapa de la consola (vacío para ninguno)",
+	"prompt.font-unimap":            "Unimap de la consola (vacío para ninguno)",
+	"prompt.ttys":                   "Número de TTYs (vacío para el predeterminado)",
+	"prompt.rc-vars":                "Variables adicionales de rc.conf:",
+	"prompt.rc-var":                 "Variable adicional de rc.conf (NOMBRE=valor, NOMBRE= para quitarla, vacío para terminar)",
+	"prompt.rc-var-format":          "Las variables se dan como NOMBRE=valor",
+	"prompt.users":                  "Usuarios:",
+	"prompt.users-edit":             "Número para editar, a para añadir, d y un número para borrar, vacío al terminar",
+	"prompt.users-choose":           "Elija un usuario del 1 al %s",
+	"prompt.user-add":               "¿Desea añadir un usuario?",
+	"prompt.user-delete":            "¿Borrar el usuario %s?",
+	"prompt.username":               "Nombre de usuario",
+	"prompt.gecos":                  "Nombre completo del usuario",
+	"prompt.shell":                  "Intérprete de inicio de sesión (vacío para el predeterminado)",
+	"prompt.root-shell":             "Intérprete para root (vacío para el predeterminado)",
+	"prompt.password":               "Contraseña para %s (! para bloquear, o pegue un hash)",
+	"prompt.password-keep":          "Contraseña para %s (! para bloquear, o pegue un hash) (vacío para mantenerla)",
+	"prompt.password-again":         "Repita la contraseña",
+	"prompt.password-none":          "¿Dejar %s sin contraseña?",
+	"prompt.password-weak":          "Esta contraseña es débil: %s",
+	"prompt.password-mismatch":      "Las contraseñas no coinciden",
+	"prompt.groups":                 "Grupos:",
+	"prompt.groups-toggle":          "Números para marcar o desmarcar, +nombre para añadir otro grupo, vacío al terminar",
+	"prompt.groups-choose":          "%s: elija un grupo del 1 al %s",
+	"prompt.ssh-keys":               "Claves SSH:",
+	"prompt.ssh-key":                "Clave pública SSH, o archivo de claves (vacío para terminar)",
+
+	"tui.title":                "Instalador de Void Linux",
+	"tui.inspecting":           "Espere mientras el instalador examina su sistema...",
+	"tui.review-install":       "Revisar e instalar",
+	"tui.quit":                 "Salir",
+	"tui.quit-question":        "¿Salir del instalador sin instalar nada?",
+	"tui.problems":             "La configuración tiene problemas que deben corregirse primero:",
+	"tui.review":               "Revisión",
+	"tui.help-review":          "Intro: continuar  Esc: volver al menú",
+	"tui.confirm":              "Confirmar la instalación",
+	"tui.confirm-question":     "El sistema se va a instalar tal como está configurado.\n\nSe pueden perder datos del destino.  ¿Continuar con la instalación?",
+	"tui.help-menu":            "Intro: elegir  Esc: volver  Escriba para buscar",
+	"tui.search":               "Buscar: %s",
+	"tui.no-match":             "No hay coincidencias",
+	"tui.help-input":           "Intro: aceptar  Esc: volver",
+	"tui.help-message":         "Intro: continuar",
+	"tui.help-yes-no":          "s: sí  n/Esc: no",
+	"tui.help-checklist":       "Espacio: marcar  Intro: aceptar  Esc: volver",
+	"tui.yes":                  "sí",
+	"tui.no":                   "no",
+	"tui.done":                 "Hecho",
+	"tui.section-disk":         "Disco",
+	"tui.section-hostname":     "Nombre del equipo",
+	"tui.section-timezone":     "Zona horaria",
+	"tui.section-locale":       "Configuración regional",
+	"tui.section-keyboard":     "Teclado",
+	"tui.section-console":      "Consola",
+	"tui.section-bootloader":   "Cargador de arranque",
+	"tui.section-packages":     "Paquetes",
+	"tui.section-network":      "Red",
+	"tui.section-services":     "Servicios",
+	"tui.section-root":         "Cuenta root",
+	"tui.section-users":        "Usuarios",
+	"tui.section-privileges":   "Privilegios",
+	"tui.hostname-label":       "Nombre del sistema, que puede ser completo:",
+	"tui.domain-label":         "Dominio del sistema, si lo hay:",
+	"tui.timezone-label":       "Zona horaria, como Europe/Madrid:",
+	"tui.timezone-other":       "Otra",
+	"tui.timezone-region":      "Zona horaria: región",
+	"tui.timezone-in":          "Zona horaria: %s",
+	"tui.locale-label":         "Configuración regional de glibc, como es_ES.UTF-8:",
+	"tui.keyboard-label":       "Distribución de teclado de la consola, como es:",
+	"tui.defaults":             "valores predeterminados",
+	"tui.customized":           "personalizada",
+	"tui.default":              "predeterminado",
+	"tui.clock":                "Reloj de hardware",
+	"tui.font":                 "Fuente",
+	"tui.font-map":             "Mapa de la fuente",
+	"tui.font-unimap":          "Unimap de la fuente",
+	"tui.font-label":           "Fuente de la consola, vacío para la predeterminada:",
+	"tui.font-map-label":       "Mapa de la consola, vacío para ninguno:",
+	"tui.font-unimap-label":    "Mapa Unicode, vacío para ninguno:",
+	"tui.ttys":                 "TTYs",
+	"tui.ttys-label":           "Número de TTYs, vacío para el predeterminado:",
+	"tui.rc-vars":              "Variables de rc.conf",
+	"tui.rc-var-label":         "%s, vacío para quitarla:",
+	"tui.rc-var-add":           "Añadir una variable",
+	"tui.rc-var-new":           "NOMBRE=valor:",
+	"tui.rc-var-format":        "las variables se dan como NOMBRE=valor",
+	"tui.not-installed":        "no instalado",
+	"tui.grub-title":           "Instalar GRUB en",
+	"tui.grub-device":          "Introducir un dispositivo",
+	"tui.no-grub":              "No instalar GRUB",
+	"tui.grub-device-label":    "Dispositivo donde instalar GRUB:",
+	"tui.grub-graphical":       "¿Usar el menú gráfico de GRUB?",
+	"tui.packages-label":       "Paquetes adicionales, separados por espacios:",
+	"tui.manager":              "Gestor",
+	"tui.manager-title":        "Gestor de red",
+	"tui.interface":            "Interfaz %s",
+	"tui.other-interface":      "Otra interfaz",
+	"tui.interface-label":      "Nombre de la interfaz:",
+	"tui.dns":                  "Servidores DNS",
+	"tui.dns-label":            "Servidores de nombres, separados por espacios, vacío para usar DHCP:",
+	"tui.wifi":                 "Redes wifi",
+	"tui.not-configured":       "sin configurar",
+	"tui.static":               "Dirección fija",
+	"tui.unconfigure":          "Sin configurar",
+	"tui.addresses-label":      "Direcciones en notación CIDR, como mucho una IPv4 y una IPv6:",
+	"tui.gateway-label":        "Puerta de enlace IPv4, vacío para ninguna:",
+	"tui.wifi-open":            "abierta",
+	"tui.wifi-protected":       "protegida",
+	"tui.wifi-title":           "Wifi",
+	"tui.wifi-forget":          "¿Olvidar la red %s?",
+	"tui.wifi-add":             "Añadir una red",
+	"tui.ssid-label":           "Nombre de la red (SSID):",
+	"tui.psk-label":            "Frase de contraseña, vacío para una red abierta:",
+	"tui.services-enable":      "Servicios a activar además de los predeterminados, separados por espacios:",
+	"tui.services-disable":     "Servicios a desactivar, separados por espacios:",
+	"tui.locked":               "bloqueada",
+	"tui.password-set":         "contraseña definida",
+	"tui.no-password":          "sin contraseña",
+	"tui.not-set":              "sin definir",
+	"tui.set":                  "definida",
+	"tui.password":             "Contraseña",
+	"tui.root-lock-wheel":      "root solo se puede bloquear cuando algún usuario está en el grupo wheel.",
+	"tui.lock-root":            "Bloquear root",
+	"tui.shell":                "Intérprete",
+	"tui.shell-label":          "Intérprete de inicio de sesión, vacío para el predeterminado:",
+	"tui.password-label":       "Contraseña, ! para bloquear la cuenta, o un hash para usarlo tal cual:",
+	"tui.password-weak":        "Esta contraseña es débil: %s.\n\n¿Usarla de todos modos?",
+	"tui.password-again":       "Repita la contraseña:",
+	"tui.password-mismatch":    "Las contraseñas no coinciden.",
+	"tui.none":                 "ninguno",
+	"tui.user-add":             "Añadir un usuario",
+	"tui.user-new":             "Nuevo usuario",
+	"tui.user":                 "Usuario",
+	"tui.user-named":           "Usuario %s",
+	"tui.username":             "Nombre de usuario",
+	"tui.username-label":       "Nombre de usuario:",
+	"tui.gecos":                "Nombre completo",
+	"tui.gecos-label":          "Nombre completo:",
+	"tui.groups":               "Grupos",
+	"tui.groups-label":         "Grupos, separados por comas:",
+	"tui.user-delete":          "Borrar este usuario",
+	"tui.user-delete-question": "¿Borrar el usuario %s?",
+	"tui.tool":                 "Herramienta",
+	"tui.wheel-password":       "wheel necesita contraseña",
+	"tui.disk-mounted":         "ya montados",
+	"tui.filesystems":          "%s sistemas de archivos",
+	"tui.disk-item":            "%s, %s particiones",
+	"tui.disk-use-mounted":     "Usar los sistemas de archivos ya montados en el destino",
+	"tui.disk-title":           "Disco donde instalar",
+	"tui.layout-title":         "Distribución de %s",
+	"tui.layout-plan":          "%s quedará distribuido así:",
+	"tui.encryption":           "Cifrado",
+	"tui.passphrase-label":     "Frase de contraseña para desbloquear %s:",
+	"tui.passphrase-weak":      "Esta frase de contraseña es débil: %s.\n\n¿Usarla de todos modos?",
+	"tui.passphrase-again":     "Repita la frase de contraseña:",
+	"tui.passphrase-mismatch":  "Las frases de contraseña no coinciden.",
+	"tui.erased":               "Se borrará lo siguiente:",
+	"tui.erase-title":          "Borrar %s",
+	"tui.erase":                "¿Borrar %s?",
+	"tui.partitions":           "particiones",
+	"tui.cfdisk":               "cfdisk escribe sus cambios en %s en cuanto se le indica, antes de que se confirme la instalación, y se pierde lo que hubiera en las particiones que cambie.",
+	"tui.partitions-title":     "Particiones de %s",
+	"tui.not-used":             "sin usar",
+	"tui.formatted":            "formateada",
+	"tui.mount-label":          "Punto de montaje, swap, o vacío para no usarla:",
+	"tui.mount-absolute":       "un punto de montaje empieza por /",
+	"tui.filesystem-title":     "Sistema de archivos de %s",
+	"tui.format":               "¿Formatear %s, borrando lo que contiene?",
+	"tui.installing":           "Instalando Void Linux",
+	"tui.help-progress":        "Flechas/RePág/AvPág: desplazar  Fin: seguir  Ctrl-C: cancelar",
+	"tui.help-finished":        "Flechas/RePág/AvPág: desplazar  Intro: salir",
+	"tui.cancelling":           "Cancelando la instalación...",
+	"tui.preparing":            "Preparando",
+	"tui.failed":               "La instalación falló, vea el registro de abajo",
+	"tui.complete":             "La instalación ha terminado",
+
+	"layout.ext4":   "un único sistema de archivos ext4",
+	"layout.btrfs":  "btrfs con subvolúmenes para / y /home, comprimido",
+	"layout.luks":   "ext4 sobre LVM en una partición cifrada, con /boot sin cifrar",
+	"layout.custom": "Particionar el disco usted mismo con cfdisk",
+
+	"group.wheel":   "puede convertirse en root con sudo o doas",
+	"group.audio":   "puede usar los dispositivos de sonido directamente",
+	"group.video":   "puede usar los dispositivos de vídeo y la aceleración por GPU",
+	"group.network": "puede gestionar las conexiones de red",
+	"group.plugdev": "puede montar dispositivos extraíbles",
+	"group.input":   "puede leer los dispositivos de entrada directamente",
+}
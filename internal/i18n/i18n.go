@@ -0,0 +1,133 @@
+// Package i18n translates what the installer and its frontends say.
+// Messages are identified by an ID and formatted from a catalogue for
+// the chosen language, falling back to English for anything that
+// hasn't been translated yet.
+package i18n
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// fallback is the language every message has a text in.
+const fallback = "en"
+
+var lang = flag.String("lang", "", "Language of the installer, such as de or pt_BR (default from LANG)")
+
+// A Catalogue maps message IDs to fmt formats.  Arguments are always
+// strings, a translation that has to put them in a different order
+// can use %[2]s and the like.
+type Catalogue map[string]string
+
+// catalogues are the languages there are translations for, by their
+// ISO 639 code, with a territory only where it makes a difference.
+var catalogues = map[string]Catalogue{
+	"en": english,
+	"de": german,
+	"es": spanish,
+	"pt": portuguese,
+}
+
+// Message is something to tell the user, which each frontend can
+// translate for its user.  A Message without an ID, such as a line
+// printed by a command, is shown as it is.
+type Message struct {
+	ID   string   `json:"id,omitempty"`
+	Args []string `json:"args,omitempty"`
+}
+
+// M returns the message with the given ID, formatting its arguments
+// as fmt's %v would.
+func M(id string, args ...interface{}) Message {
+	m := Message{ID: id}
+	for _, a := range args {
+		m.Args = append(m.Args, fmt.Sprint(a))
+	}
+	return m
+}
+
+// Text returns a message that isn't translated.
+func Text(s string) Message {
+	return Message{Args: []string{s}}
+}
+
+// String returns the message in the chosen language.
+func (m Message) String() string {
+	return m.In(Language())
+}
+
+// In returns the message in the given language.
+func (m Message) In(language string) string {
+	if m.ID == "" {
+		return strings.Join(m.Args, " ")
+	}
+	format, ok := catalogues[Resolve(language)][m.ID]
+	if !ok {
+		format, ok = english[m.ID]
+	}
+	if !ok {
+		// A message nobody wrote a text for is still better
+		// shown than lost.
+		return strings.TrimSpace(m.ID + " " + strings.Join(m.Args, " "))
+	}
+	args := make([]interface{}, len(m.Args))
+	for n, a := range m.Args {
+		args[n] = a
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// T returns the message with the given ID in the chosen language.
+func T(id string, args ...interface{}) string {
+	return M(id, args...).String()
+}
+
+// Language returns the language messages are shown in: the one given
+// with -lang, or else the one the live system is set to in the usual
+// environment variables.
+func Language() string {
+	if *lang != "" {
+		return Resolve(*lang)
+	}
+	for _, v := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if l := os.Getenv(v); l != "" {
+			return Resolve(l)
+		}
+	}
+	return fallback
+}
+
+// Resolve finds the catalogue for a locale such as pt_BR.UTF-8, which
+// is the one for pt_BR if there is one and for pt otherwise.  English
+// is used for languages there are no translations for.
+func Resolve(locale string) string {
+	l := locale
+	if n := strings.IndexAny(l, ".@"); n >= 0 {
+		l = l[:n]
+	}
+	l = strings.Replace(l, "-", "_", -1)
+	if _, ok := catalogues[l]; ok {
+		return l
+	}
+	if n := strings.Index(l, "_"); n >= 0 {
+		l = l[:n]
+	}
+	l = strings.ToLower(l)
+	if _, ok := catalogues[l]; ok {
+		return l
+	}
+	return fallback
+}
+
+// Languages returns the languages there are translations for.
+func Languages() []string {
+	out := []string{}
+	for l := range catalogues {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
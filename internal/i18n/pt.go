@@ -0,0 +1,330 @@
+package i18n
+
+var portuguese = Catalogue{
+	"installer.step":                    "Passo %s de %s: %s",
+	"installer.resuming":                "Retomando a instalação após o passo %q",
+	"installer.completed":               "Instalação em %s concluída",
+	"installer.cancelled":               "Instalação cancelada durante o passo %q",
+	"installer.failed":                  "A instalação falhou durante o passo %q: %s",
+	"installer.no-steps":                "  Nenhum passo foi concluído, %s ainda pode conter arquivos parciais",
+	"installer.completed-steps":         "  Passos concluídos: %s",
+	"installer.partial":                 "  %s contém um sistema instalado parcialmente",
+	"installer.rolled-back":             "  A configuração do destino foi revertida:",
+	"installer.cleanup-failed":          "  A limpeza não teve sucesso, %s precisa ser feito à mão",
+	"installer.formatting":              "Formatando %s como %s",
+	"installer.erasing":                 "Apagando %s e organizando-o como %s",
+	"installer.dry-run":                 "Simulação, nada é alterado.  Estes comandos seriam executados:",
+	"installer.opening":                 "Abrindo %s",
+	"installer.initramfs":               "Configurando o initramfs para desbloquear o sistema de arquivos raiz",
+	"installer.initramfs-done":          "  O initramfs foi regenerado",
+	"installer.keys":                    "Instalando as chaves",
+	"installer.packages":                "Instalando pacotes adicionais",
+	"installer.configuring":             "Configurando %s",
+	"installer.configured":              "  %s foi configurado",
+	"installer.names":                   "Configurando os nomes de rede",
+	"installer.localtime":               "  /etc/localtime aponta para %s",
+	"installer.xkb-guess":               "  %s não tem um layout do X11 conhecido, será adivinhado",
+	"installer.users":                   "Adicionando contas de usuário",
+	"installer.users-added":             "  Contas de usuário adicionadas",
+	"installer.user-exists":             "  %s já existe",
+	"installer.locked":                  "  %s foi bloqueado",
+	"installer.no-home":                 "  %s não tem diretório pessoal, as chaves SSH não serão instaladas",
+	"installer.ssh-keys":                "  %s chave(s) SSH instalada(s) para %s",
+	"installer.no-group":                "  O grupo %s não existe, ignorando-o",
+	"installer.group-created":           "  Grupo %s criado",
+	"installer.root":                    "Configurando a conta root",
+	"installer.root-not-locked":         "  root não será bloqueado, nenhum usuário está no grupo wheel",
+	"installer.root-shell":              "  O shell do root é %s",
+	"installer.network":                 "Configurando a rede",
+	"installer.services":                "Ativando serviços",
+	"installer.service-enabled":         "  %s ativado em %s",
+	"installer.service-down":            "  %s ativado em %s, não iniciado",
+	"installer.service-already-enabled": "  %s já está ativado em %s",
+	"installer.service-disabled":        "  %s desativado em %s",
+	"installer.files":                   "Escrevendo arquivos adicionais",
+
+	"prompt.yes":                    "sim",
+	"prompt.no":                     "não",
+	"prompt.welcome":                "Bem-vindo ao instalador do Void Linux",
+	"prompt.inspecting":             "Aguarde enquanto o instalador examina o seu sistema...",
+	"prompt.defaults-help":          "Pressione Enter para manter o valor entre colchetes, ou digite - para apagá-lo.",
+	"prompt.choice":                 "Escolha",
+	"prompt.count":                  "%s na lista:",
+	"prompt.count-titled":           "%s, %s na lista:",
+	"prompt.echo":                   "Escolhido: %s",
+	"prompt.echo-empty":             "Nada escolhido",
+	"prompt.checked":                "marcado",
+	"prompt.unchecked":              "desmarcado",
+	"prompt.keep":                   "Mantê-los?",
+	"prompt.use-anyway":             "Usar mesmo assim?",
+	"prompt.proceed-question":       "Deseja prosseguir com a instalação?",
+	"prompt.proceed":                "Prosseguir (sim/não)",
+	"prompt.review":                 "Revise a sua configuração:",
+	"prompt.review-section":         "Seção a alterar (vazio para continuar)",
+	"prompt.review-problems":        "Corrija primeiro os problemas marcados com !",
+	"prompt.review-choose":          "Escolha uma seção de 1 a %s",
+	"prompt.section-disk":           "Disco",
+	"prompt.section-hostname":       "Nome da máquina",
+	"prompt.section-timezone":       "Fuso horário",
+	"prompt.section-locale":         "Localidade",
+	"prompt.section-bootloader":     "Carregador de inicialização",
+	"prompt.section-keyboard":       "Teclado",
+	"prompt.section-packages":       "Pacotes",
+	"prompt.section-network":        "Rede",
+	"prompt.section-console":        "Console",
+	"prompt.section-users":          "Usuários",
+	"prompt.section-root-password":  "Senha do root",
+	"prompt.section-root-shell":     "Shell do root",
+	"prompt.summary-layout":         "%s apagado, %s",
+	"prompt.summary-filesystem":     "%s em %s",
+	"prompt.summary-mounted":        "sistemas de arquivos já montados",
+	"prompt.summary-no-grub":        "não instalado",
+	"prompt.summary-grub":           "GRUB em %s",
+	"prompt.summary-grub-graphical": "GRUB em %s, gráfico",
+	"prompt.summary-wifi":           "wifi %s",
+	"prompt.summary-clock":          "relógio %s",
+	"prompt.summary-font":           "fonte %s",
+	"prompt.summary-ttys":           "%s ttys",
+	"prompt.summary-rc-vars":        "%s variáveis do rc.conf",
+	"prompt.summary-defaults":       "padrões",
+	"prompt.summary-locked":         "bloqueada",
+	"prompt.summary-not-set":        "não definida",
+	"prompt.summary-set":            "definida",
+	"prompt.console-skip":           "Configurar opções avançadas do console?",
+	"prompt.disks":                  "Discos:",
+	"prompt.disk-live":              "(%s é de onde o instalador está sendo executado e não pode receber a instalação)",
+	"prompt.disk-mounted":           "Instalar em sistemas de arquivos já montados no destino",
+	"prompt.disk":                   "Disco para a instalação (vazio para manter a escolha atual)",
+	"prompt.disk-choose":            "Escolha um disco de 1 a %s, ou m",
+	"prompt.layouts":                "Esquemas:",
+	"prompt.layout":                 "Esquema",
+	"prompt.layout-choose":          "Escolha um esquema de 1 a %s",
+	"prompt.layout-plan":            "%s será organizado assim:",
+	"prompt.passphrase":             "Frase secreta para desbloquear %s",
+	"prompt.passphrase-again":       "Repita a frase secreta",
+	"prompt.passphrase-weak":        "Esta frase secreta é fraca: %s",
+	"prompt.passphrase-mismatch":    "As frases secretas não coincidem",
+	"prompt.erased":                 "Isto será apagado:",
+	"prompt.erase":                  "Apagar %s?",
+	"prompt.these-partitions":       "estas partições",
+	"prompt.cfdisk":                 "O cfdisk grava as suas alterações em %s assim que for mandado, antes de a instalação ser confirmada, e o que havia nas partições alteradas é perdido.",
+	"prompt.mount-point":            "Ponto de montagem, ou swap (vazio para não usar)",
+	"prompt.mount-point-absolute":   "um ponto de montagem começa com /",
+	"prompt.filesystem-type":        "Tipo de sistema de arquivos",
+	"prompt.format":                 "Formatar %s?",
+	"prompt.hostname":               "Nome da máquina",
+	"prompt.domain":                 "Domínio (vazio para nenhum)",
+	"prompt.timezone":               "Digite o seu fuso horário",
+	"prompt.timezone-browse":        "Digite o seu fuso horário (? para navegar, /nome para buscar)",
+	"prompt.timezone-regions":       "Regiões:",
+	"prompt.timezone-other":         "Outro",
+	"prompt.timezone-zones":         "Fusos:",
+	"prompt.timezone-none":          "Nenhum fuso horário corresponde",
+	"prompt.timezone-matching":      "Fusos horários correspondentes:",
+	"prompt.locale":                 "Digite a sua localidade da glibc",
+	"prompt.grub":                   "Usar o GRUB?",
+	"prompt.grub-graphical":         "Usar o GRUB gráfico?",
+	"prompt.grub-disk":              "Instalar o GRUB em",
+	"prompt.grub-no-disk":           "é preciso um disco para instalar o GRUB",
+	"prompt.keyboard":               "Digite o layout do seu teclado (? para listar, /nome para buscar)",
+	"prompt.xorg":                   "Instalar um ambiente gráfico (xorg)?",
+	"prompt.packages":               "Pacotes adicionais (separados por espaços)",
+	"prompt.network-manager":        "Gerenciador de rede, dhcpcd ou NetworkManager",
+	"prompt.interfaces-configured":  "Interfaces configuradas:",
+	"prompt.interfaces":             "Interfaces de rede:",
+	"prompt.interface":              "Interface a configurar (vazio para DHCP em todas)",
+	"prompt.address":                "Endereço em notação CIDR (vazio para DHCP)",
+	"prompt.gateway":                "Gateway (vazio para nenhum)",
+	"prompt.dns":                    "Servidores DNS (separados por espaços, vazio para DHCP)",
+	"prompt.wifi-configured":        "Redes wifi:",
+	"prompt.wifi":                   "Rede wifi para conectar (vazio para terminar)",
+	"prompt.wifi-passphrase":        "Senha (vazio se aberta)",
+	"prompt.clock":                  "Relógio de hardware, UTC ou localtime (vazio para UTC)",
+	"prompt.font":                   "Fonte do console (vazio para a padrão)",
+	"prompt.font-map":               "Mapa do console (vazio para nenhum)",
+	"prompt.font-unimap":            "Unimap do console (vazio para nenhum)",
+	"prompt.ttys":                   "Número de TTYs (vazio para o padrão)",
+	"prompt.rc-vars":                "Variáveis adicionais do rc.conf:",
+	"prompt.rc-var":                 "Variável adicional do rc.conf (NOME=valor, NOME= para remover, vazio para terminar)",
+	"prompt.rc-var-format":          "As variáveis devem ser dadas como NOME=valor",
+	"prompt.users":                  "Usuários:",
+	"prompt.users-edit":             "Número para editar, a para adicionar, d e um número para apagar, vazio ao terminar",
+	"prompt.users-choose":           "Escolha um usuário de 1 a %s",
+	"prompt.user-add":               "Deseja adicionar um usuário?",
+	"prompt.user-delete":            "Apagar o usuário %s?",
+	"prompt.username":               "Nome de usuário",
+	"prompt.gecos":                  "Nome completo do usuário",
+	"prompt.shell":                  "Shell de login (vazio para o padrão)",
+	"prompt.root-shell":             "Shell do root (vazio para o padrão)",
+	"prompt.password":               "Senha para %s (! para bloquear, ou cole um hash)",
+	"prompt.password-keep":          "Senha para %s (! para bloquear, ou cole um hash) (vazio para manter)",
+	"prompt.password-again":         "Repita a senha",
+	"prompt.password-none":          "Deixar %s sem senha?",
+	"prompt.password-weak":          "Esta senha é fraca: %s",
+	"prompt.password-mismatch":      "As senhas não coincidem",
+	"prompt.groups":                 "Grupos:",
+	"prompt.groups-toggle":          "Números para marcar ou desmarcar, +nome para adicionar outro grupo, vazio ao terminar",
+	"prompt.groups-choose":          "%s: escolha um grupo de 1 a %s",
+	"prompt.ssh-keys":               "Chaves SSH:",
+	"prompt.ssh-key":                "Chave pública SSH, ou arquivo de chaves (vazio para terminar)",
+
+	"tui.title":                "Instalador do Void Linux",
+	"tui.inspecting":           "Aguarde enquanto o instalador examina o seu sistema...",
+	"tui.review-install":       "Revisar e instalar",
+	"tui.quit":                 "Sair",
+	"tui.quit-question":        "Sair do instalador sem instalar nada?",
+	"tui.problems":             "A configuração tem problemas que precisam ser corrigidos primeiro:",
+	"tui.review":               "Revisão",
+	"tui.help-review":          "Enter: continuar  Esc: voltar ao menu",
+	"tui.confirm":              "Confirmar a instalação",
+	"tui.confirm-question":     "O sistema será instalado conforme configurado.\n\nDados no destino podem ser perdidos.  Prosseguir com a instalação?",
+	"tui.help-menu":            "Enter: escolher  Esc: voltar  Digite para buscar",
+	"tui.search":               "Buscar: %s",
+	"tui.no-match":             "Nada corresponde",
+	"tui.help-input":           "Enter: aceitar  Esc: voltar",
+	"tui.help-message":         "Enter: continuar",
+	"tui.help-yes-no":          "s: sim  n/Esc: não",
+	"tui.help-checklist":       "Espaço: marcar  Enter: aceitar  Esc: voltar",
+	"tui.yes":                  "sim",
+	"tui.no":                   "não",
+	"tui.done":                 "Concluído",
+	"tui.section-disk":         "Disco",
+	"tui.section-hostname":     "Nome da máquina",
+	"tui.section-timezone":     "Fuso horário",
+	"tui.section-locale":       "Localidade",
+	"tui.section-keyboard":     "Teclado",
+	"tui.section-console":      "Console",
+	"tui.section-bootloader":   "Carregador de inicialização",
+	"tui.section-packages":     "Pacotes",
+	"tui.section-network":      "Rede",
+	"tui.section-services":     "Serviços",
+	"tui.section-root":         "Conta root",
+	"tui.section-users":        "Usuários",
+	"tui.section-privileges":   "Privilégios",
+	"tui.hostname-label":       "Nome do sistema, que pode ser completo:",
+	"tui.domain-label":         "Domínio do sistema, se houver:",
+	"tui.timezone-label":       "Fuso horário, como America/Sao_Paulo:",
+	"tui.timezone-other":       "Outro",
+	"tui.timezone-region":      "Fuso horário: região",
+	"tui.timezone-in":          "Fuso horário: %s",
+	"tui.locale-label":         "Localidade da glibc, como pt_BR.UTF-8:",
+	"tui.keyboard-label":       "Mapa de teclado do console, como br-abnt2:",
+	"tui.defaults":             "padrões",
+	"tui.customized":           "personalizado",
+	"tui.default":              "padrão",
+	"tui.clock":                "Relógio de hardware",
+	"tui.font":                 "Fonte",
+	"tui.font-map":             "Mapa da fonte",
+	"tui.font-unimap":          "Unimap da fonte",
+	"tui.font-label":           "Fonte do console, vazio para a padrão:",
+	"tui.font-map-label":       "Mapa do console, vazio para nenhum:",
+	"tui.font-unimap-label":    "Mapa Unicode, vazio para nenhum:",
+	"tui.ttys":                 "TTYs",
+	"tui.ttys-label":           "Número de TTYs, vazio para o padrão:",
+	"tui.rc-vars":              "Variáveis do rc.conf",
+	"tui.rc-var-label":         "%s, vazio para remover:",
+	"tui.rc-var-add":           "Adicionar uma variável",
+	"tui.rc-var-new":           "NOME=valor:",
+	"tui.rc-var-format":        "as variáveis devem ser dadas como NOME=valor",
+	"tui.not-installed":        "não instalado",
+	"tui.grub-title":           "Instalar o GRUB em",
+	"tui.grub-device":          "Digitar um dispositivo",
+	"tui.no-grub":              "Não instalar o GRUB",
+	"tui.grub-device-label":    "Dispositivo onde instalar o GRUB:",
+	"tui.grub-graphical":       "Usar o menu gráfico do GRUB?",
+	"tui.packages-label":       "Pacotes adicionais, separados por espaços:",
+	"tui.manager":              "Gerenciador",
+	"tui.manager-title":        "Gerenciador de rede",
+	"tui.interface":            "Interface %s",
+	"tui.other-interface":      "Outra interface",
+	"tui.interface-label":      "Nome da interface:",
+	"tui.dns":                  "Servidores DNS",
+	"tui.dns-label":            "Servidores de nomes, separados por espaços, vazio para usar DHCP:",
+	"tui.wifi":                 "Redes wifi",
+	"tui.not-configured":       "não configurada",
+	"tui.static":               "Endereço fixo",
+	"tui.unconfigure":          "Não configurada",
+	"tui.addresses-label":      "Endereços em notação CIDR, no máximo um IPv4 e um IPv6:",
+	"tui.gateway-label":        "Gateway IPv4, vazio para nenhum:",
+	"tui.wifi-open":            "aberta",
+	"tui.wifi-protected":       "protegida",
+	"tui.wifi-title":           "Wifi",
+	"tui.wifi-forget":          "Esquecer a rede %s?",
+	"tui.wifi-add":             "Adicionar uma rede",
+	"tui.ssid-label":           "Nome da rede (SSID):",
+	"tui.psk-label":            "Senha, vazio para uma rede aberta:",
+	"tui.services-enable":      "Serviços a ativar além dos padrões, separados por espaços:",
+	"tui.services-disable":     "Serviços a desativar, separados por espaços:",
+	"tui.locked":               "bloqueada",
+	"tui.password-set":         "senha definida",
+	"tui.no-password":          "sem senha",
+	"tui.not-set":              "não definida",
+	"tui.set":                  "definida",
+	"tui.password":             "Senha",
+	"tui.root-lock-wheel":      "O root só pode ser bloqueado quando algum usuário estiver no grupo wheel.",
+	"tui.lock-root":            "Bloquear o root",
+	"tui.shell":                "Shell",
+	"tui.shell-label":          "Shell de login, vazio para o padrão:",
+	"tui.password-label":       "Senha, ! para bloquear a conta, ou um hash para usar como está:",
+	"tui.password-weak":        "Esta senha é fraca: %s.\n\nUsar mesmo assim?",
+	"tui.password-again":       "Repita a senha:",
+	"tui.password-mismatch":    "As senhas não coincidem.",
+	"tui.none":                 "nenhum",
+	"tui.user-add":             "Adicionar um usuário",
+	"tui.user-new":             "Novo usuário",
+	"tui.user":                 "Usuário",
+	"tui.user-named":           "Usuário %s",
+	"tui.username":             "Nome de usuário",
+	"tui.username-label":       "Nome de usuário:",
+	"tui.gecos":                "Nome completo",
+	"tui.gecos-label":          "Nome completo:",
+	"tui.groups":               "Grupos",
+	"tui.groups-label":         "Grupos, separados por vírgulas:",
+	"tui.user-delete":          "Apagar este usuário",
+	"tui.user-delete-question": "Apagar o usuário %s?",
+	"tui.tool":                 "Ferramenta",
+	"tui.wheel-password":       "wheel precisa de senha",
+	"tui.disk-mounted":         "já montados",
+	"tui.filesystems":          "%s sistemas de arquivos",
+	"tui.disk-item":            "%s, %s partições",
+	"tui.disk-use-mounted":     "Usar os sistemas de arquivos já montados no destino",
+	"tui.disk-title":           "Disco para a instalação",
+	"tui.layout-title":         "Esquema de %s",
+	"tui.layout-plan":          "%s será organizado assim:",
+	"tui.encryption":           "Criptografia",
+	"tui.passphrase-label":     "Frase secreta para desbloquear %s:",
+	"tui.passphrase-weak":      "Esta frase secreta é fraca: %s.\n\nUsar mesmo assim?",
+	"tui.passphrase-again":     "Repita a frase secreta:",
+	"tui.passphrase-mismatch":  "As frases secretas não coincidem.",
+	"tui.erased":               "Isto será apagado:",
+	"tui.erase-title":          "Apagar %s",
+	"tui.erase":                "Apagar %s?",
+	"tui.partitions":           "partições",
+	"tui.cfdisk":               "O cfdisk grava as suas alterações em %s assim que for mandado, antes de a instalação ser confirmada, e o que havia nas partições alteradas é perdido.",
+	"tui.partitions-title":     "Partições de %s",
+	"tui.not-used":             "não usada",
+	"tui.formatted":            "formatada",
+	"tui.mount-label":          "Ponto de montagem, swap, ou vazio para não usar:",
+	"tui.mount-absolute":       "um ponto de montagem começa com /",
+	"tui.filesystem-title":     "Sistema de arquivos de %s",
+	"tui.format":               "Formatar %s, apagando o que houver nela?",
+	"tui.installing":           "Instalando o Void Linux",
+	"tui.help-progress":        "Setas/PgUp/PgDn: rolar  End: acompanhar  Ctrl-C: cancelar",
+	"tui.help-finished":        "Setas/PgUp/PgDn: rolar  Enter: sair",
+	"tui.cancelling":           "Cancelando a instalação...",
+	"tui.preparing":            "Preparando",
+	"tui.failed":               "A instalação falhou, veja o registro abaixo",
+	"tui.complete":             "A instalação foi concluída",
+
+	"layout.ext4":   "um único sistema de arquivos ext4",
+	"layout.btrfs":  "btrfs com subvolumes para / e /home, comprimido",
+	"layout.luks":   "ext4 sobre LVM numa partição criptografada, com /boot sem criptografia",
+	"layout.custom": "Particionar o disco você mesmo com o cfdisk",
+
+	"group.wheel":   "pode se tornar root com sudo ou doas",
+	"group.audio":   "pode usar os dispositivos de som diretamente",
+	"group.video":   "pode usar os dispositivos de vídeo e a aceleração por GPU",
+	"group.network": "pode gerenciar as conexões de rede",
+	"group.plugdev": "pode montar dispositivos removíveis",
+	"group.input":   "pode ler os dispositivos de entrada diretamente",
+}
@@ -0,0 +1,216 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// readDB reads one of the colon separated account databases, such as
+// /etc/passwd, from the target.
+func (i *Installer) readDB(path string) ([][]string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(i.target, path))
+	if err != nil {
+		return nil, err
+	}
+	entries := [][]string{}
+	for _, l := range strings.Split(string(b), "\n") {
+		if l == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		entries = append(entries, strings.Split(l, ":"))
+	}
+	return entries, nil
+}
+
+// lookupUser finds a user in the target's /etc/passwd.
+func (i *Installer) lookupUser(name string) (uid, gid int, home string, err error) {
+	entries, err := i.readDB("etc/passwd")
+	if err != nil {
+		return 0, 0, "", err
+	}
+	for _, e := range entries {
+		if len(e) < 6 || e[0] != name {
+			continue
+		}
+		uid, err := strconv.Atoi(e[2])
+		if err != nil {
+			return 0, 0, "", err
+		}
+		gid, err := strconv.Atoi(e[3])
+		if err != nil {
+			return 0, 0, "", err
+		}
+		return uid, gid, e[5], nil
+	}
+	return 0, 0, "", fmt.Errorf("user %s does not exist in the target", name)
+}
+
+// groupExists checks the target's /etc/group for a group, by name or
+// by GID.
+func (i *Installer) groupExists(group string) (bool, error) {
+	entries, err := i.readDB("etc/group")
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if len(e) > 2 && (e[0] == group || e[2] == group) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// homeDir returns where useradd will put a user's home directory,
+// relative to the target.
+func homeDir(u config.User) string {
+	if u.Home != "" {
+		return strings.TrimPrefix(filepath.Clean(u.Home), "/")
+	}
+	return filepath.Join("home", u.Username)
+}
+
+// installSSHKeys writes a user's authorized_keys, owned by the user
+// and readable only by them, as sshd insists.
+func (i *Installer) installSSHKeys(u config.User) error {
+	keys := append([]string{}, u.SSHKeys...)
+	for _, f := range u.SSHKeyFiles {
+		fileKeys, err := config.ReadSSHKeyFile(f)
+		if err != nil {
+			i.Errors <- err
+			return err
+		}
+		keys = append(keys, fileKeys...)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	uid, gid, home, err := i.lookupUser(u.Username)
+	if err != nil {
+		i.Errors <- err
+		return err
+	}
+	home = strings.TrimPrefix(filepath.Clean(home), "/")
+	if _, err := os.Stat(filepath.Join(i.target, home)); err != nil {
+		i.Output <- i18n.M("installer.no-home", u.Username)
+		return nil
+	}
+
+	sshDir := filepath.Join(home, ".ssh")
+	if err := i.backupFile(sshDir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(i.target, sshDir), 0700); err != nil {
+		i.Errors <- err
+		return err
+	}
+	authorized := filepath.Join(sshDir, "authorized_keys")
+	if err := i.writeFile(authorized, []byte(strings.Join(keys, "\n")+"\n"), 0600); err != nil {
+		return err
+	}
+	for _, p := range []string{sshDir, authorized} {
+		if err := os.Chown(filepath.Join(i.target, p), uid, gid); err != nil {
+			i.Errors <- err
+			return err
+		}
+	}
+	i.Output <- i18n.M("installer.ssh-keys", len(keys), u.Username)
+	return nil
+}
+
+// addUser creates an account in the target.  Only the options that
+// are set are passed to useradd.  Groups that don't exist in the
+// target are created if the configuration asks for it, and skipped
+// otherwise.  Users that already exist, as they will when resuming,
+// are left alone.
+func (i *Installer) addUser(ctx context.Context, u config.User) error {
+	if _, _, _, err := i.lookupUser(u.Username); err == nil {
+		i.Output <- i18n.M("installer.user-exists", u.Username)
+		return nil
+	}
+
+	args := []string{"useradd"}
+	if u.NoCreateHome {
+		args = append(args, "-M")
+	} else {
+		args = append(args, "-m")
+	}
+	if u.GID != 0 {
+		gid := strconv.Itoa(u.GID)
+		exists, err := i.groupExists(gid)
+		if err != nil {
+			i.Errors <- err
+			return err
+		}
+		if !exists {
+			if err := i.chroot(ctx, "groupadd", "-g", gid, u.Username); err != nil {
+				return err
+			}
+		}
+		args = append(args, "-g", gid)
+	} else {
+		args = append(args, "-U")
+	}
+	if u.UID != 0 {
+		args = append(args, "-u", strconv.Itoa(u.UID))
+	}
+	if u.Shell != "" {
+		args = append(args, "-s", u.Shell)
+	}
+	if u.Home != "" {
+		args = append(args, "-d", u.Home)
+	}
+	if u.System {
+		args = append(args, "-r")
+	}
+	if u.GECOS != "" {
+		args = append(args, "-c", u.GECOS)
+	}
+
+	groups, err := i.ensureGroups(ctx, u.Groups)
+	if err != nil {
+		return err
+	}
+	if len(groups) > 0 {
+		args = append(args, "-G", strings.Join(groups, ","))
+	}
+
+	return i.chroot(ctx, append(args, u.Username)...)
+}
+
+// ensureGroups returns the groups that a user can be added to,
+// creating those that are missing if configured to.
+func (i *Installer) ensureGroups(ctx context.Context, groups []string) ([]string, error) {
+	out := []string{}
+	for _, g := range groups {
+		g = strings.TrimSpace(g)
+		if g == "" {
+			continue
+		}
+		exists, err := i.groupExists(g)
+		if err != nil {
+			i.Errors <- err
+			return nil, err
+		}
+		if !exists {
+			if !i.Config.CreateGroups {
+				i.Output <- i18n.M("installer.no-group", g)
+				continue
+			}
+			if err := i.chroot(ctx, "groupadd", g); err != nil {
+				return nil, err
+			}
+			i.Output <- i18n.M("installer.group-created", g)
+		}
+		out = append(out, g)
+	}
+	return out, nil
+}
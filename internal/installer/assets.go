@@ -1,9 +1,17 @@
 // Code generated by go-bindata.
 // sources:
+// templates/00-keyboard.conf
+// templates/crypttab
+// templates/dhcpcd.conf
+// templates/doas.conf
+// templates/dracut.conf
 // templates/fstab
 // templates/hosts
 // templates/locale.conf
 // templates/rc.conf
+// templates/resolv.conf
+// templates/sudoers
+// templates/wpa_supplicant.conf
 // DO NOT EDIT!
 
 package installer
@@ -71,7 +79,107 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesFstab = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\x8d\xc1\x6a\x44\x21\x0c\x45\xd7\xf1\x2b\x02\xb3\x69\x61\xb0\xb3\xe9\x4e\x5c\x95\xee\x4a\x0b\x33\x3f\x60\x31\x16\xe1\xa9\xe1\x25\x16\x1e\xe2\xbf\x97\x57\xb3\x3a\x27\x70\xef\xbd\x98\x0b\xde\x89\x30\x89\x86\xef\xa7\xd7\x67\x6b\xce\x8f\x4b\x79\x23\x94\x43\x94\x8a\x07\x17\xf3\xee\xc1\xe9\xc1\xe4\xc1\x35\xd6\xdc\xaa\x78\x00\x17\x7b\x61\x0f\x8e\x83\x88\x37\x5a\x38\x09\xc0\x8b\x16\x86\xc5\x91\x52\xe8\x9b\xca\xb5\x36\xe9\x39\x5e\x6b\x8b\xf4\x8b\x88\x37\x5c\x77\x33\x63\xe0\x1e\xea\x0f\xa1\xc5\x39\xcd\x18\xf6\xfd\x3e\x27\x8e\x61\x3f\x5a\xaf\xfa\x68\x4b\x1e\x07\xd3\xa2\xcf\xb5\xbd\xe4\xad\x17\x5e\xf4\x15\x44\xfe\xf3\x48\x35\x9e\x4d\x7f\x01\x00\x00\xff\xff\x55\x90\xa6\x77\xd6\x00\x00\x00")
+var _templates00KeyboardConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\xc1\x6a\x83\x40\x10\x86\xef\x3e\xc5\xcf\xe6\xd2\x42\x15\x72\xea\x03\x84\x1e\xa4\x2d\x3d\x04\x4a\xae\xa3\x8e\x71\x71\x9d\x15\x77\x93\x46\x16\xdf\xbd\xa8\x9b\x04\x42\xf6\xb8\xf3\xcd\xff\xcd\xbf\xc1\x27\x8f\x85\xa5\xa1\x82\xa1\xd1\x9e\x3c\x6a\x3b\xe0\xb0\xdd\xbe\xa1\x23\x5f\x36\x5a\x8e\xf0\x0d\xa3\xb4\xe2\xac\x61\xb4\x3c\x76\xd4\x23\x84\x6c\x67\xa5\xd6\xc7\xec\xba\x3e\x4d\x59\xb2\xc1\x9e\x19\x97\x36\x7e\xa5\xe5\x82\xbc\xbc\xbf\x2e\xa1\x73\x0c\x9d\x49\x1b\x2a\x0c\x47\x9b\x03\x49\x85\x33\x0d\x9a\xc4\xbb\x2c\xd9\x73\xe9\xb5\x15\xa8\x5c\xfa\x93\xdf\x19\x72\x4e\x25\x88\x2f\xaf\x58\xbc\xae\x35\x0f\x50\x6e\x74\x9e\xbb\xf4\xaa\xba\x43\xdf\xf3\xd5\xb9\xbb\xb5\x52\x56\xee\xc3\x9f\x7e\x4d\x3f\xb4\xc5\xd7\xe2\x57\x50\x21\xe0\xd2\x16\xb1\xfc\x63\x2b\x4c\x93\x4a\x42\x48\xf1\xa7\x7d\x33\x73\xf1\xd4\x67\xe0\x33\xcb\xef\x8a\xaf\x9a\xec\x96\xc6\xb2\x2c\x7c\x48\x15\x0b\x27\xff\x03\x00\x78\xcd\x52\x21\x88\x01\x00\x00")
+
+func templates00KeyboardConfBytes() ([]byte, error) {
+	return bindataRead(
+		_templates00KeyboardConf,
+		"templates/00-keyboard.conf",
+	)
+}
+
+func templates00KeyboardConf() (*asset, error) {
+	bytes, err := templates00KeyboardConfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/00-keyboard.conf", size: 392, mode: os.FileMode(420), modTime: time.Unix(1792380284, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesCrypttab = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x34\x8c\xb1\x0e\xc2\x20\x14\x45\x67\xf8\x8a\x97\xb0\xe8\xc2\xe6\x86\x2c\x76\x31\x31\x2e\xa6\x1f\x80\xe5\x19\x89\x16\x48\xa1\x36\x0d\x79\xff\x6e\x28\xe9\x76\xee\x49\xee\x11\xf0\x40\x84\x61\x5a\x63\xce\xe6\x79\x38\x1d\x25\x17\x5c\x80\xf2\x66\x44\xcd\x94\xc5\x9f\x1b\x2a\x44\x93\xd2\x12\x26\xab\x99\x0a\x31\xbb\xe0\x93\xe6\xa5\xc0\xe2\xf2\x1b\xe4\xcd\xac\x61\xce\x40\x54\x0a\xb8\x17\xc8\x4b\xcd\x75\xdb\x75\x93\x4d\xdc\xcd\x88\x44\xac\xef\xaf\xdd\x79\x77\x75\x10\x31\x1f\x3c\xb2\xef\xfc\x49\xb5\x89\xde\xb6\x54\x03\xfe\x1f\x00\xc9\xab\xb4\x84\xa3\x00\x00\x00")
+
+func templatesCrypttabBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesCrypttab,
+		"templates/crypttab",
+	)
+}
+
+func templatesCrypttab() (*asset, error) {
+	bytes, err := templatesCrypttabBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/crypttab", size: 163, mode: os.FileMode(420), modTime: time.Unix(1792383646, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDhcpcdConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x52\x41\x4f\xdc\x3c\x10\xbd\xe7\x57\x8c\xc4\xe5\xfb\x54\x6a\x44\xa1\x7b\xa8\xd4\x03\xda\x08\x9a\x43\x57\x88\x65\xcf\x91\x49\x26\xc4\xc2\xb1\x53\xcf\x64\xb7\xc8\xca\x7f\xaf\xc6\x59\xb2\x41\xb4\x37\x67\xe6\xbd\x37\xf3\x5e\xe6\x0c\x2e\x90\xab\x8b\xba\xad\xfa\xaa\x56\x95\x77\xcd\x37\x38\x04\xc3\x8c\x0e\x9e\x5e\x61\x5f\x38\x62\x6d\x2d\x86\x73\x20\x44\x58\xe0\xfe\xfb\xfa\x7f\x96\x9d\x41\xe1\x1a\x1f\x3a\xe0\x16\x21\xff\xb1\xbe\x07\xc2\xb0\xc7\x00\xbe\x01\x3f\x04\x68\x3d\xb1\xd3\x1d\x42\xe3\x03\xe4\xf9\x66\xab\xb2\xb7\x92\x90\x77\x84\x89\x49\x02\xc9\x77\x45\x0e\x9f\xa0\xb8\x29\x72\xd0\x04\x84\x0c\xc6\x25\xd1\xfd\x6a\xe2\xcb\xf3\x1a\xd6\xd6\xa0\xe3\x09\xd4\x63\x80\x87\xdb\xf5\xf5\xd5\xea\x52\x65\xf5\x60\x6a\x51\xbd\xc7\x40\x86\x84\xcd\x18\x1a\x5d\x21\xc8\xbe\xe6\x79\x08\x9a\x8d\x77\x70\x68\xd1\x1d\x9d\x00\xfe\x36\x4c\x2a\xeb\x27\x0a\x3a\x16\x81\x07\xdd\x9b\x1a\x2a\xdf\x75\x86\x81\x86\xbe\xf7\x81\x55\xe6\xfb\xc4\x0e\xd2\x2c\xa7\xa6\x80\x6f\xc0\xca\x30\x31\x9c\x00\x04\xec\x21\xe0\xaf\x01\x89\xa1\x09\xfe\x43\x36\xb3\x52\xed\x3b\x6d\x5c\x29\x61\x94\x53\x8b\xce\x97\xc5\xf9\x83\x50\x87\xaa\x3d\x4f\x69\xa6\xce\x9b\x42\x65\x35\x91\x45\xa2\x92\x58\xb3\xa9\xca\xe0\x07\x46\x12\x0b\x48\x3d\x56\x9c\x66\x3b\xe4\x83\x0f\x2f\xf0\xf3\x71\xa7\xe0\xb1\x35\x04\x86\x40\xf7\xbd\x35\x58\xcb\xb2\x69\xb7\x89\x39\xef\x36\x67\x57\x76\x3c\x4c\x36\xb7\x69\xc5\x22\x17\xb6\xf8\x33\x01\x6b\xb9\x91\x87\xdb\xf5\x97\xcb\xab\x4b\x95\x1d\x8b\x29\xda\xa3\xa1\xd2\xd4\xe8\xd8\x34\x06\x83\x88\xdc\xa1\xc3\xa0\x19\x61\xcb\xfa\xc9\x22\xdc\x07\xb3\x97\xcf\x42\x7e\xf1\x4d\x5d\x07\x24\x42\x82\x27\x4d\x58\x2f\xb2\xdb\x15\x79\x46\x56\xeb\x0a\xfa\x89\x90\xc5\xf8\x19\x0e\x86\x5b\x50\xeb\xf4\x6b\xd5\x66\xf2\x38\x8e\xa9\x65\x1a\x50\xf9\x66\x3b\x8e\x32\xf4\x51\x22\x90\x03\x3b\x66\x2c\x57\x95\x8e\x3e\x20\x79\xbb\x4f\xc7\x0c\x3a\x60\xba\xb8\x29\x47\x6d\xed\xab\xca\x9c\x6f\xbd\x7f\x81\x05\x2c\x89\xa3\xab\x17\x63\x8a\xb7\xa0\x48\xa6\x69\x6b\xfd\x61\xce\x8e\x62\x0c\xda\x3d\xe3\x7b\x14\xc4\xa8\x36\xba\xc3\x71\x8c\xf1\xa4\x75\x7a\xfd\x85\x32\x4f\x9b\x33\x92\x61\xf3\x9c\x93\xe2\x52\x60\x89\x9d\x6c\x41\x8c\xa6\x01\xd3\xef\x57\xa0\xc6\xd1\xf4\xab\x52\x4f\x98\x18\xd1\x12\x4a\x69\x51\x91\x85\xbe\xc7\xa8\x3e\x2c\x28\x8b\xdc\x69\xc6\x83\x7e\x3d\x49\xa7\xfb\x09\x24\x84\x53\xef\x3d\xef\xdf\xaf\x3f\x03\x00\x98\x88\x82\x33\x86\x04\x00\x00")
+
+func templatesDhcpcdConfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDhcpcdConf,
+		"templates/dhcpcd.conf",
+	)
+}
+
+func templatesDhcpcdConf() (*asset, error) {
+	bytes, err := templatesDhcpcdConfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/dhcpcd.conf", size: 1158, mode: os.FileMode(420), modTime: time.Unix(1792381830, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDoasConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x90\xb1\x4e\xc3\x30\x10\x86\xf7\x3c\xc5\xa9\xed\xd0\x4a\x90\xd2\x81\xa5\x12\x53\x17\x58\x50\x85\x84\x98\x4d\x7c\x4e\x8d\x1c\xbb\xf2\xb9\x0d\xe8\x74\xef\x8e\xec\x46\x90\x66\x82\xed\x74\xe7\xff\xf3\xa7\x7f\x5e\xcd\x61\x8d\xa9\x59\xeb\xa0\xa8\x6e\x82\x37\x5b\xe8\xa3\x4d\x09\x3d\xbc\x7f\xc1\xf9\xc9\x53\x52\xce\x61\xbc\x01\x42\x84\x9f\x57\xcb\xfb\x55\x35\xaf\x98\x6f\xa1\xb7\xe9\x00\xf5\x2e\x78\x63\x5b\x91\xb2\xb2\x06\xea\x47\x45\x6f\x07\x44\xf7\x4a\x18\x45\xaa\xea\x88\xb1\xb3\x09\x98\xf3\x71\x1f\xed\xd9\x3a\x6c\xb1\x7e\x0e\x7b\x45\xd4\x87\xa8\x45\x7c\x38\x2a\x22\x66\x74\x84\x22\x47\x8c\x64\x29\x31\xa3\xd7\x22\xb0\xed\x33\xad\xe0\xcb\xa2\x4c\x51\xf9\x16\xc7\xb8\x97\x93\x43\x1a\x8e\x8b\x78\x72\x08\xdb\x07\xa8\x47\x5a\xbb\xd0\x75\xca\x6b\xba\x06\x4c\xb6\x0b\x93\x63\xc6\xa2\xd3\x54\xd2\x63\xf9\x82\xfd\xbb\x37\xf3\x25\x70\xe9\x01\x9a\x4e\x67\x8c\xd7\xf8\x99\xbf\xb9\xfb\x55\x6b\x13\x2c\x1d\x7a\x58\x98\x15\x6c\x44\x40\xc5\x96\x80\xf9\x23\x58\x0f\x4b\x72\xb6\xc1\x1c\xd8\xac\x60\x06\x33\x91\x01\x3f\xe9\xe3\x62\x70\xdd\xf5\x3f\x4c\x07\xc9\x29\x74\x32\x7d\x0f\x00\xa1\x49\xf9\x1d\x33\x02\x00\x00")
+
+func templatesDoasConfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDoasConf,
+		"templates/doas.conf",
+	)
+}
+
+func templatesDoasConf() (*asset, error) {
+	bytes, err := templatesDoasConfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/doas.conf", size: 563, mode: os.FileMode(420), modTime: time.Unix(1792381737, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesDracutConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\xcb\xc1\x4a\xc4\x30\x14\x85\xe1\x7d\x9f\xe2\x50\x97\x42\xe6\x09\xba\x52\x10\x61\xdc\x88\x33\xdb\x12\x9b\xab\x73\x99\x24\xb7\x24\x37\x95\x12\xf2\xee\x92\xce\xf6\x7c\xff\x79\xc2\xd7\x8d\x90\x44\x14\x3f\xec\x29\xef\x59\x29\x80\x33\x24\xe2\x7c\xfd\x00\xc7\xcc\x8e\x60\x23\x28\x2e\x69\x5f\x95\x1c\x56\x9b\x94\x95\x25\x9a\xc1\x3a\x37\xbb\x64\x97\xa2\x41\x5c\xf1\x94\x9f\xa7\x11\x47\x07\xbf\x05\x8c\x03\xc7\xac\xd6\xfb\x99\x95\xc2\x81\x27\xd2\xe5\x74\x14\x6a\xbf\x31\x0e\xb5\xe2\x8f\xf5\x06\x73\xb6\xbb\x14\x45\x6b\x77\x4a\x91\xfc\xbc\x04\xe7\x39\xd2\x34\x26\x67\x7c\xb9\x67\x53\x0a\xbb\xa9\x56\xf3\xd2\xcf\x97\xcb\xfb\x6b\x6b\xe8\xb6\x05\xb3\xfd\x76\xb8\x8a\x2f\x81\xde\x92\x94\xb5\x93\x88\xf6\xf5\x53\x44\x1f\xd2\xda\x58\x2b\x28\x3a\xb4\x36\xfc\x0f\x00\xcc\x40\xc9\x6a\xf9\x00\x00\x00")
+
+func templatesDracutConfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDracutConf,
+		"templates/dracut.conf",
+	)
+}
+
+func templatesDracutConf() (*asset, error) {
+	bytes, err := templatesDracutConfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/dracut.conf", size: 249, mode: os.FileMode(420), modTime: time.Unix(1792383646, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesFstab = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x24\x8e\xbd\x6a\x43\x31\x0c\x85\x67\xf9\x29\x04\x59\x5a\x08\x6e\x96\x6e\xc6\x4b\x4b\xb6\xd2\x42\xf2\x02\x2e\x96\x83\xe1\xfa\x87\x48\x2e\x5c\x8c\xdf\xbd\x24\xd2\xf4\x7d\x02\xe9\x9c\x83\x39\xe0\x85\x08\x13\x4b\xf8\x7d\x79\x7f\xb5\xe6\xb1\x71\x29\x6f\x84\xbc\xb3\x50\xf1\xe0\x62\xbe\x7b\x70\xb2\x77\xf2\xe0\x5a\x97\xdc\x2a\x7b\x00\x17\x47\xe9\x1e\x5c\x0f\xcc\xde\x48\xe9\x89\x01\xde\xa4\x74\x50\x8e\x94\xc2\xd8\x84\x8f\xb5\xf1\xc8\xf1\x58\x5b\xa4\x3f\x44\x3c\xa1\xce\xc9\xcc\x89\xf7\x50\x6f\x84\xf6\xa3\xd5\x94\x6f\xf6\x9c\x37\xd2\x58\xc6\xb5\xcc\x9c\xf6\x7c\x59\x0b\xe7\xb4\x5f\x6d\x54\xb9\x36\x95\xeb\xde\x49\xe9\x5b\xdb\xa8\x7c\x8e\xd2\x95\x7e\x02\xf3\xf3\x1e\xa9\xc6\xc7\xa7\xff\x01\x00\x86\xb7\xc2\x40\xe8\x00\x00\x00")
 
 func templatesFstabBytes() ([]byte, error) {
 	return bindataRead(
@@ -86,12 +194,12 @@ func templatesFstab() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/fstab", size: 214, mode: os.FileMode(420), modTime: time.Unix(1538087934, 0)}
+	info := bindataFileInfo{name: "templates/fstab", size: 232, mode: os.FileMode(420), modTime: time.Unix(1792380091, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesHosts = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x64\xca\x31\xae\x83\x30\x0c\x80\xe1\xd9\x3e\x85\xa5\xcc\x18\x78\xc3\xab\x84\x10\x5b\x0f\xe2\x92\xd0\x46\x0d\x18\xc5\xe9\x84\xb8\x7b\x95\x89\xa1\xeb\xff\xfd\x0e\x1d\xb5\xa1\xcc\xed\x4b\xad\xd8\x40\x56\xa4\xc4\x99\x92\xea\xfb\xb3\x53\x91\x47\x0a\xb4\x68\xa6\xca\xb4\xc9\x1a\x0c\x1d\xa2\x1b\xe3\xde\x88\xf7\x39\x98\x4d\x00\x63\xd5\x8a\xec\x75\x95\xb8\xb1\xe6\xe7\x74\xd5\x09\xfb\xbf\x1b\x77\xdc\x71\x0f\x70\x1c\x7c\x9e\x90\x74\x96\x54\x1d\x87\xa1\x87\x9f\x4a\x71\xff\x6f\xae\x07\x1d\xdd\x37\x4f\xba\xd0\x12\x53\xc0\x6f\x00\x00\x00\xff\xff\x7f\xdf\x2a\xb0\xb4\x00\x00\x00")
+var _templatesHosts = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\xcd\x6e\xc3\x20\x10\x84\xcf\xf0\x14\xab\x70\xf6\x26\xee\xa1\x95\xac\xc8\x97\xfe\xa8\xbd\x44\xad\xfa\x04\xc4\x80\x4d\x43\x58\x0b\xa8\x7a\xb0\x78\xf7\x0a\xa7\x52\xa2\xb8\xb9\xed\xce\x7c\x0c\xa3\x15\x5c\xc0\x5a\xa7\x6e\x3d\x50\x4c\xb1\x81\x98\x64\xb2\x1d\x38\xa2\xc3\xf7\x08\x49\xee\x9d\x06\x43\x01\x8a\x0d\x5e\x1e\x75\xe4\x82\x73\xb1\xb5\x63\x25\x95\x0a\x3a\xc6\x96\xb1\x6d\x71\x8b\x89\x8a\x8e\xd2\x7a\xa4\xd0\xb7\x67\xb5\xe5\xf5\xdd\x03\x6e\x70\x83\x35\x63\x8e\x3a\xe9\x8a\xc3\x9b\xa6\x66\x17\x3b\xd8\xf1\xbe\xba\xde\x68\xdc\xcb\xee\xc0\xa7\xa9\x82\x1f\x9b\x06\xc0\x47\xf2\xc6\xf6\x39\xcf\x92\x35\x80\xaf\x7f\x9f\x9c\x25\xaf\x01\x5f\x3e\x9e\x76\x80\x9f\x03\x85\x74\x01\x9c\x6a\xd4\xa5\xc6\x34\xcd\x4c\xce\x65\xba\xe6\x4a\x90\x76\x71\xf9\xe4\x5f\xd0\xab\xc5\x14\xa4\xef\xf5\xa9\x5b\x9c\x25\x7c\x7b\xcf\xb9\x44\x7c\x91\xf5\x80\xbb\x72\x48\x58\xb1\xd5\x8d\x0c\x2e\xe0\xd9\x2b\x20\x03\xc6\x3a\xcd\x7f\x07\x00\xc9\x3a\xcf\xbd\xa5\x01\x00\x00")
 
 func templatesHostsBytes() ([]byte, error) {
 	return bindataRead(
@@ -106,12 +214,12 @@ func templatesHosts() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/hosts", size: 180, mode: os.FileMode(420), modTime: time.Unix(1538087982, 0)}
+	info := bindataFileInfo{name: "templates/hosts", size: 421, mode: os.FileMode(420), modTime: time.Unix(1792381905, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesLocaleConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xf2\x71\xf4\x73\xb7\xad\xae\xd6\xab\xad\xe5\xf2\x71\x8e\x77\xf6\xf7\xf1\x71\x0c\x71\xb5\x75\xe6\x02\x04\x00\x00\xff\xff\x68\xdb\xaa\xb7\x18\x00\x00\x00")
+var _templatesLocaleConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xf2\x71\xf4\x73\xb7\xad\xae\xd6\x73\xce\xcf\x4b\xcb\x4c\xd7\xf3\xc9\x4f\x4e\xcc\x49\xad\xad\xe5\xf2\x71\x8e\x77\xf6\xf7\xf1\x71\x0c\x71\xb5\x75\xe6\x02\x0c\x00\xa5\x34\x2c\x6d\x25\x00\x00\x00")
 
 func templatesLocaleConfBytes() ([]byte, error) {
 	return bindataRead(
@@ -126,12 +234,12 @@ func templatesLocaleConf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/locale.conf", size: 24, mode: os.FileMode(420), modTime: time.Unix(1538088006, 0)}
+	info := bindataFileInfo{name: "templates/locale.conf", size: 37, mode: os.FileMode(420), modTime: time.Unix(1792380091, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _templatesRcConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8e\x51\x6b\xd4\x40\x14\x85\x9f\x9d\x5f\x71\x49\x1e\x54\xe8\x26\xf8\x22\x5a\x88\x10\x62\x4a\x4b\xdc\x44\x76\x67\x91\xf6\x45\x66\x93\x1b\x33\x38\x99\xbb\xcc\xdc\x6c\x89\xa5\xff\x5d\x26\xda\xc5\x65\x5f\x7c\x9b\x39\xdc\xef\x7c\x27\x86\x14\xb9\x4d\x5d\x9b\xb4\x64\x7b\x58\x81\x9f\x3d\xe3\x08\xe1\xa7\x7f\x4c\x4e\xb1\x26\x0b\x3d\x39\x38\x92\xee\x84\x88\x61\x8b\x0c\x3c\x20\x0c\xe4\x19\xac\x1a\x31\x11\xb1\x88\xa1\x6e\x64\x79\x0d\x9a\x5f\x7b\x38\x38\xec\xd1\x39\xec\x80\x09\x3a\x6c\x8d\x72\x78\x42\x02\x01\xda\xfe\xd1\xfe\x13\x78\x46\xd5\x5d\x8b\x18\x5e\xad\x00\xdb\x81\x60\x9c\x17\xc3\xa7\xf3\xcb\xe0\xba\x6d\xb6\xb2\xce\xd7\x65\x16\x85\x4d\x2b\xa3\x8f\x18\xbd\x2c\xdb\xc8\x22\x58\x77\xb2\x00\x72\x60\xa8\x55\x86\xf5\xb2\xf1\x36\xdf\x7c\xfe\x96\x6f\xca\xe2\x4b\x53\x54\x59\xb4\x93\xc5\x09\x0a\x17\xbf\xc8\xe2\x15\xa8\xa3\xd2\x46\xed\x0d\xfa\x53\xe8\x41\x31\xa4\x93\x77\xa9\x1f\x94\xc3\x34\x64\xda\xf6\x94\x08\x79\xb7\x2e\x1f\x9a\xba\xcc\xa2\xa7\xa7\x44\xea\x11\x1f\xc8\xe2\xf3\xf3\x52\x5b\xe1\x3c\xaa\x43\x98\x62\x48\x75\x57\xe0\x11\x97\xd7\x4f\x9c\xfd\x9b\x0f\x6f\x13\x51\x95\xf7\xeb\xfc\xeb\x82\x56\x38\xef\x49\xb9\xee\x2f\x5a\x90\xf5\x64\x10\x7a\xb2\x7c\x5e\xe0\x91\x43\xb8\xf0\xf1\x4d\x53\xcb\x2c\x32\x8a\x3f\x3e\xae\xde\xbd\x3f\x23\x2f\xcc\x17\xe0\xf7\xe0\x0e\xc8\x4d\x90\x4c\x56\xff\x07\xb2\xab\xef\x5e\xa8\x7c\xa4\xc9\x32\x50\x0f\xcc\xb3\x87\xc7\x41\xb7\x03\xf8\x81\x26\xd3\xc1\x7e\x61\xa7\x43\x22\x62\x29\xef\xb7\x99\xf8\x1d\x00\x00\xff\xff\x73\x44\x1d\xc2\x63\x02\x00\x00")
+var _templatesRcConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x94\x93\xd1\xab\xda\x30\x14\xc6\x9f\xd7\xbf\xe2\x60\x2f\x6c\x03\xad\xec\x65\x6c\x82\x03\xe9\x7a\xf1\xe2\xd4\xa1\x75\xc3\xfb\x32\x62\x73\x6a\xc3\x4d\x13\x97\xa4\x4a\x57\xf2\xbf\x8f\xd4\x2a\xad\x57\x06\x7b\x32\x9e\x7c\xe7\xfb\x7d\xe7\xd0\xf8\x30\x44\x93\x0c\x55\x12\x24\x52\xa4\x30\x00\x5d\x6a\x83\x39\xb8\x7f\x6c\x5f\x28\x62\x98\x14\x90\x4a\x05\x47\xc9\xa8\xe7\xf9\xb0\x46\x03\x26\x43\xc8\xa4\x36\x20\x48\x8e\x81\xe7\x7b\x3e\x2c\x96\x71\x34\x02\x66\xde\x6a\x38\x28\x4c\x51\x29\xa4\x60\x24\x50\x4c\x38\x51\x78\x6d\x71\x1d\xc0\xc4\x19\xdb\x2a\x68\x83\x84\x8e\x3c\x1f\xde\x0c\x00\x93\x4c\x42\x5e\xd6\x84\x2f\x5d\xa5\x63\x4d\x97\xeb\x78\x31\x99\x47\xe3\x9e\xcb\x34\xe0\xec\x88\xbd\x4b\xb2\x55\x1c\x3a\xea\x26\x0e\x41\x2a\xe0\x32\x21\xdc\x30\x97\xb1\xaa\x80\xa5\x10\x84\xf5\x5c\xc1\x94\x28\x7a\x22\x0a\x43\x2e\x93\x17\xb0\x76\x3a\x59\x7d\xfd\x39\x59\x45\xe1\xb7\x65\x38\x1b\xf7\xaa\xea\xae\xd0\xda\x5e\x55\x01\x72\x8d\x60\xad\x7f\xd3\xb3\x89\xc3\xfa\x56\x50\xb0\xf6\xba\x28\x96\xe3\x1f\x29\xb0\x0f\xe4\x48\x18\x27\x3b\x8e\xfa\x5a\xd4\x40\x0c\x0c\x0b\xad\x86\x3a\x23\x0a\x87\xae\xc6\x44\x2a\x03\x2f\x7e\x9a\x47\xcf\xcb\x45\xd4\x4e\x12\xb3\x1c\x9f\xa5\x40\x6b\xeb\x61\x67\x58\xe6\xe4\xe0\x66\xe5\x92\xd0\x3e\x68\xc4\xfa\xf4\x82\xa5\x7e\xf7\xe9\x7d\xe0\xcd\xa2\xed\x7c\xf2\xbd\xed\x30\xc3\x72\x27\x89\xa2\x8d\x43\x28\x85\x96\x1c\x21\x95\xc2\x74\x7d\x34\x1a\x57\xac\x6d\xba\x7b\x7b\x74\x5a\x6b\x1f\x97\x8b\xb8\xed\xec\xca\xdd\xe5\x9c\x15\x9c\x98\xcf\xa7\xc1\x87\x8f\xdd\xc5\x5c\xc0\xaf\xf2\xff\x9b\x3b\x27\x87\x06\xfd\xeb\x66\xb0\xe6\xf6\x75\x82\x5a\xd8\x61\xd7\x03\x14\x82\xfd\x27\x7b\x73\xee\x68\xf0\x9b\xc5\xd3\x9d\x04\x67\xcd\x9d\x10\x8d\xbc\x93\x63\x92\xcb\x42\x18\x90\x29\x18\x53\x6a\x38\x65\x2c\xc9\x40\x67\xb2\xe0\x14\x76\x75\x9a\xe2\x70\x1b\x24\x8e\xb7\x6b\xb0\xd6\xfd\x8c\x5b\xdf\x45\xbc\x5d\x5b\xdb\x62\x36\xf7\x17\x58\x55\x0d\xda\x26\xab\xf0\x07\x51\xfa\x92\x82\x52\xe6\x1e\x38\xe1\x8e\x68\x98\xd8\xeb\xa0\x6e\x50\x44\xec\x11\x1e\xdc\x8b\xeb\xc3\xc3\x91\xf0\x02\x61\x34\xbe\x63\x52\x55\x67\x15\x58\xeb\x98\xbf\x0b\x69\xf0\xd2\xd0\xc0\x5b\x39\x9a\xe3\xdf\x01\x00\x12\x8c\xdd\x7f\x75\x04\x00\x00")
 
 func templatesRcConfBytes() ([]byte, error) {
 	return bindataRead(
@@ -146,7 +254,67 @@ func templatesRcConf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/rc.conf", size: 611, mode: os.FileMode(420), modTime: time.Unix(1538088223, 0)}
+	info := bindataFileInfo{name: "templates/rc.conf", size: 1141, mode: os.FileMode(420), modTime: time.Unix(1792380158, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesResolvConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4c\xcb\xb1\x0a\xc2\x30\x10\x06\xe0\x3d\x4f\x71\xe0\xa2\xa0\xd7\xc9\xc5\x55\x17\x97\x2e\x3e\x41\xac\x7f\x4b\x31\x5e\xe0\x72\x24\x48\xb8\x77\x17\x9c\xba\x7f\xdf\x8e\x06\xd8\x34\x28\x4a\x4e\x95\xa7\x2c\xf3\x85\x9a\xae\x66\x10\x7a\x7e\xa9\xde\xa5\x58\x4c\x09\x7a\xa4\x02\xd0\xc6\xed\xcf\x87\xd0\xfb\x89\x34\xca\x02\xe2\x6b\x96\x79\x5d\x78\x84\xb5\xac\x6f\xbe\x8d\x0f\xf7\x20\xf1\x83\x02\xad\x50\xea\x9d\xdd\xff\x01\xf2\x72\x0f\xbf\x01\x00\x2d\xa5\xe3\x60\x79\x00\x00\x00")
+
+func templatesResolvConfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesResolvConf,
+		"templates/resolv.conf",
+	)
+}
+
+func templatesResolvConf() (*asset, error) {
+	bytes, err := templatesResolvConfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/resolv.conf", size: 121, mode: os.FileMode(420), modTime: time.Unix(1792381830, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesSudoers = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8e\xcd\x4a\x03\x31\x10\xc7\xef\x79\x8a\xa1\x8b\xa0\xd0\x66\xef\x05\x0f\x4b\x3d\x28\x84\xba\x58\xa4\xe7\x68\x66\xb7\x23\x69\x02\x99\x74\x17\x09\x79\x77\x49\xf6\x50\x51\xe8\x6d\xe6\x37\xf3\xff\x68\x44\x03\x2d\xc6\xcf\x96\x2f\xc6\x63\x60\x69\xda\xf9\x84\x68\xb7\x30\x07\x8a\x11\x1d\x7c\x7c\xc3\xf4\xe2\x38\x6a\x6b\x31\xac\x01\x0d\x45\x98\x29\x9e\x60\xa2\xa2\x81\xcd\x20\x1a\x91\xd2\x66\x81\x72\xe7\xdd\x40\x63\xce\x15\xd1\x00\xf2\x59\xf3\xb1\x38\xbe\x33\x86\x9c\x85\xb8\xab\xfe\xd0\x29\xf5\x78\xdf\x29\xf5\x00\x29\x95\xb7\x3e\xd0\x44\x16\x47\x94\x7b\xdf\x6b\xe6\xd9\x07\x93\xf3\xfe\xb5\xef\x0e\x87\xe3\xd3\x16\x52\x42\x67\x72\xee\x94\xaa\xce\x75\xa9\x53\xd0\x6e\xc4\xdf\xfa\xb7\x8b\x45\xae\x47\xb9\x64\xfe\xcb\xba\x99\xb0\xbc\xec\xfc\xf9\xac\x9d\xe1\xb2\x7f\x79\x72\x57\x02\xab\x35\xac\x0a\x46\xcb\x58\x0b\xa5\x74\x6d\xf3\x77\xfa\x19\x00\x89\x6d\x58\x57\x60\x01\x00\x00")
+
+func templatesSudoersBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesSudoers,
+		"templates/sudoers",
+	)
+}
+
+func templatesSudoers() (*asset, error) {
+	bytes, err := templatesSudoersBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/sudoers", size: 352, mode: os.FileMode(420), modTime: time.Unix(1792381737, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesWpaSupplicantConf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\x8c\x51\x4b\xfb\x30\x14\x47\x9f\x97\x4f\x71\xe9\x9f\x3f\xe8\x83\x19\x82\xf8\x20\xe4\xc9\x89\x0c\x61\x1b\x1b\xe2\x63\x89\xd9\x6d\x0d\xcd\x6e\x63\x72\x6b\x1d\x21\xdf\x5d\xda\x5a\x90\xbd\xe5\x97\x73\xee\xf9\x07\x4b\x64\xb3\xec\xbd\x2e\x63\xe7\xbd\xb3\x46\x13\x5f\x4c\x69\x5a\xaa\x1e\xa0\x0f\x96\x19\x09\xde\xcf\xf0\xb5\xa6\xc8\xda\x39\x0c\xc2\x70\x70\xa5\x25\xc6\x50\x69\x83\x6a\xb5\xde\xab\x65\xe8\xe8\x22\x01\xcf\xfb\xed\xeb\x4e\xf5\x1f\x88\x4e\x74\xfe\xa8\x19\xcb\xa1\x6a\x6b\x75\x2b\x52\xba\x81\xa0\xa9\x46\x90\x8f\xe3\x9f\xdc\x20\xf7\x6d\x68\xe4\x9b\xad\x6c\xce\x42\xd0\xb4\x55\x12\x8b\x18\xed\x51\xa5\xe4\x83\x25\xae\xa0\xf8\xff\x5d\x80\x3c\x1c\xd6\xab\x9c\xc5\x22\x1a\x4d\xe5\x28\x4c\x51\x5b\x01\xb5\x0c\x72\x77\x78\x19\x70\x83\xe7\xf2\x54\x9f\x58\x6d\xb6\x9b\xa7\x51\x40\x17\x71\xb0\xf0\x13\xae\x1c\xd2\x68\x5e\xc3\xfd\xdd\x60\xfb\xd8\xa8\x94\x7e\x6f\x67\x79\x06\xc5\x4c\x8a\x09\xd1\x31\x67\x91\xff\xbc\x7f\x06\x00\x59\x5b\x52\x05\x58\x01\x00\x00")
+
+func templatesWpaSupplicantConfBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesWpaSupplicantConf,
+		"templates/wpa_supplicant.conf",
+	)
+}
+
+func templatesWpaSupplicantConf() (*asset, error) {
+	bytes, err := templatesWpaSupplicantConfBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/wpa_supplicant.conf", size: 344, mode: os.FileMode(420), modTime: time.Unix(1792381830, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -203,10 +371,18 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"templates/00-keyboard.conf": templates00KeyboardConf,
+	"templates/crypttab": templatesCrypttab,
+	"templates/dhcpcd.conf": templatesDhcpcdConf,
+	"templates/doas.conf": templatesDoasConf,
+	"templates/dracut.conf": templatesDracutConf,
 	"templates/fstab": templatesFstab,
 	"templates/hosts": templatesHosts,
 	"templates/locale.conf": templatesLocaleConf,
 	"templates/rc.conf": templatesRcConf,
+	"templates/resolv.conf": templatesResolvConf,
+	"templates/sudoers": templatesSudoers,
+	"templates/wpa_supplicant.conf": templatesWpaSupplicantConf,
 }
 
 // AssetDir returns the file names below a certain
@@ -250,10 +426,18 @@ type bintree struct {
 }
 var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
+		"00-keyboard.conf": &bintree{templates00KeyboardConf, map[string]*bintree{}},
+		"crypttab": &bintree{templatesCrypttab, map[string]*bintree{}},
+		"dhcpcd.conf": &bintree{templatesDhcpcdConf, map[string]*bintree{}},
+		"doas.conf": &bintree{templatesDoasConf, map[string]*bintree{}},
+		"dracut.conf": &bintree{templatesDracutConf, map[string]*bintree{}},
 		"fstab": &bintree{templatesFstab, map[string]*bintree{}},
 		"hosts": &bintree{templatesHosts, map[string]*bintree{}},
 		"locale.conf": &bintree{templatesLocaleConf, map[string]*bintree{}},
 		"rc.conf": &bintree{templatesRcConf, map[string]*bintree{}},
+		"resolv.conf": &bintree{templatesResolvConf, map[string]*bintree{}},
+		"sudoers": &bintree{templatesSudoers, map[string]*bintree{}},
+		"wpa_supplicant.conf": &bintree{templatesWpaSupplicantConf, map[string]*bintree{}},
 	}},
 }}
 
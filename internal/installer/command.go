@@ -0,0 +1,124 @@
+package installer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// errNoCommand is returned if runCommand is called without arguments.
+var errNoCommand = errors.New("no command to run")
+
+// command makes the commands the installer runs.  It is a variable
+// so that tests can stand in for the programs that change disks.
+var command = exec.CommandContext
+
+// runCommand runs a command, sending everything it prints to the
+// frontend.  Arguments are passed to the command exactly as given,
+// there is no shell in between.
+func (i *Installer) runCommand(ctx context.Context, args ...string) error {
+	return i.runCommandInput(ctx, nil, args...)
+}
+
+// runCommandInput runs a command with stdin connected to input.  This
+// is how secrets are handed to commands, as they would otherwise end
+// up in the process list.
+func (i *Installer) runCommandInput(ctx context.Context, input io.Reader, args ...string) error {
+	if len(args) == 0 {
+		i.Errors <- errNoCommand
+		return errNoCommand
+	}
+	cmd := command(ctx, args[0], args[1:]...)
+	cmd.Stdin = input
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		log.Printf("could not get stderr pipe: %v", err)
+		i.Errors <- err
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		log.Printf("could not get stdout pipe: %v", err)
+		i.Errors <- err
+		return err
+	}
+
+	log.Printf("$ %s", i.redact(quoteArgs(args)))
+	if err := cmd.Start(); err != nil {
+		log.Printf("could not run cmd: %v", err)
+		i.Errors <- err
+		return err
+	}
+
+	// The pipes have to be drained before Wait is called, or the
+	// last of the output may be lost.
+	var wg sync.WaitGroup
+	for _, r := range []io.Reader{stderr, stdout} {
+		wg.Add(1)
+		go func(r io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(r)
+			for scanner.Scan() {
+				msg := i.redact(scanner.Text())
+				i.Output <- i18n.Text(msg)
+				log.Println(msg)
+			}
+		}(r)
+	}
+	wg.Wait()
+
+	if err := cmd.Wait(); err != nil {
+		log.Printf("could not wait for cmd: %v", err)
+		i.Errors <- err
+		return err
+	}
+	return nil
+}
+
+// commandOutput runs a command that only reports something, and
+// returns what it printed rather than sending it to the frontend.
+func (i *Installer) commandOutput(ctx context.Context, args ...string) (string, error) {
+	if len(args) == 0 {
+		i.Errors <- errNoCommand
+		return "", errNoCommand
+	}
+	log.Printf("$ %s", i.redact(quoteArgs(args)))
+	out, err := command(ctx, args[0], args[1:]...).Output()
+	if err != nil {
+		log.Printf("could not run cmd: %v", err)
+		i.Errors <- err
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// chroot runs a command inside the target.
+func (i *Installer) chroot(ctx context.Context, args ...string) error {
+	return i.runCommand(ctx, append([]string{"chroot", i.target}, args...)...)
+}
+
+// chrootInput runs a command inside the target with stdin connected
+// to input.
+func (i *Installer) chrootInput(ctx context.Context, input io.Reader, args ...string) error {
+	return i.runCommandInput(ctx, input, append([]string{"chroot", i.target}, args...)...)
+}
+
+// quoteArgs formats a command for the log so that arguments with
+// spaces in them can still be told apart.
+func quoteArgs(args []string) string {
+	out := make([]string, len(args))
+	for n, a := range args {
+		if a == "" || strings.ContainsAny(a, " \t'\"\\$") {
+			a = shellQuote(a)
+		}
+		out[n] = a
+	}
+	return strings.Join(out, " ")
+}
@@ -1,98 +1,249 @@
 package installer
 
 import (
-	"bufio"
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
-	"text/template"
 
 	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/keyboard"
 	"github.com/the-maldridge/vInstaller/internal/keys"
-
-	"github.com/mattn/go-shellwords"
+	"github.com/the-maldridge/vInstaller/internal/layout"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+	"github.com/the-maldridge/vInstaller/internal/timezone"
 )
 
 // Installer is a type to contain channels and other assets on the
 // install process.
 type Installer struct {
 	Config *config.Config
-	Output chan string
+	Output chan i18n.Message
 	Errors chan error
 	Done   chan bool
 
 	Meta *config.Meta
 
-	target string
+	// System describes the machine being installed on, it is
+	// made available to templates.  It is discovered if not
+	// provided.
+	System *sysinfo.System
+
+	// Resume continues an installation that was interrupted,
+	// skipping the steps recorded in the target's journal.
+	Resume bool
+
+	// Rollback reverts the configuration steps if the
+	// installation fails, leaving the target as base-system
+	// shipped it.
+	Rollback bool
+
+	// DryRun only shows what would be done to the disks, nothing
+	// is changed.
+	DryRun bool
+
+	target  string
+	journal *journal
+
+	// plan is how the disk of the layout is partitioned, if
+	// there is a layout.
+	plan *layout.Plan
+
+	// cleanups are run in reverse order once the installation
+	// stops, regardless of why it stopped.
+	cleanups []cleanup
+
+	// current is the step that is presently running, completed
+	// are the steps that have finished successfully.
+	current   string
+	completed []string
+
+	// secrets are redacted from everything that is logged or
+	// sent to the frontend.
+	secrets []string
+
+	// undo holds the actions that revert the changes made so
+	// far, backedUp the files that have already been saved.
+	undo     []undoAction
+	backedUp map[string]bool
 }
 
-func mountSpecials() error {
-	log.Println("Mounting special filesystems")
-	return nil
+// A cleanup undoes some change to the host, such as a mount, that
+// must not outlive the installer.
+type cleanup struct {
+	desc string
+	fn   func() error
 }
 
-func unmountSpecials() error {
-	log.Println("Unmounting special filesystems")
-	return nil
+// A step is a single phase of the installation.  The inputs are the
+// parts of the configuration the step depends on, they are hashed
+// into the journal to detect changes when resuming.
+type step struct {
+	name   string
+	fn     func(context.Context) error
+	inputs interface{}
 }
 
-func (i *Installer) runCommand(cmdstr string) error {
-	args, err := shellwords.Parse(cmdstr)
-	if err != nil {
-		log.Printf("could not get stderr pipe: %v", err)
-		i.Errors <- err
-		return err
+// specials are the filesystems from the live system that are bound
+// into the target so that commands run in the chroot behave.
+var specials = []string{"dev", "proc", "sys"}
+
+func (i *Installer) steps() []step {
+	return []step{
+		{"base-system", i.installBaseSystem, i.Meta.Mirror},
+		{"packages", i.installPackages, i.packages()},
+		{"hostname", i.configureHostname, []interface{}{
+			i.Config.Hostname,
+			i.Config.Domain,
+			i.Config.Hosts,
+		}},
+		{"timezone", i.configureTimeZone, i.Config.TimeZone},
+		{"rc.conf", i.configureRCconf, []interface{}{
+			i.Config.TimeZone,
+			i.Config.Keyboard,
+			i.Config.HardwareClock,
+			i.Config.Font,
+			i.Config.FontMap,
+			i.Config.FontUnimap,
+			i.Config.TTYS,
+			i.Config.RCVars,
+		}},
+		{"locale.conf", i.configureLocaleconf, i.Config.Locale},
+		{"keyboard", i.configureKeyboard, []interface{}{i.Config.Keyboard, i.Config.Packages}},
+		{"fstab", i.configureFStab, i.Config.Filesystems},
+		{"storage", i.configureStorage, []interface{}{
+			i.Config.Layout.Disk,
+			i.Config.Layout.Scheme,
+		}},
+		{"users", i.addUsers, usersInputs(i.Config.Users)},
+		{"root", i.configureRoot, []interface{}{
+			hideSecret(i.Config.RootPassword),
+			i.Config.RootPasswordHash,
+			i.Config.LockRoot,
+			i.Config.RootShell,
+			i.Config.HasWheelUser(),
+		}},
+		{"privilege", i.configurePrivilege, []interface{}{
+			i.Config.Privilege,
+			i.Config.HasWheelUser(),
+		}},
+		{"network", i.configureNetwork, networkInputs(i.Config.Network)},
+		{"services", i.enableServices, i.services()},
+		{"files", i.writeFiles, i.Config.Files},
 	}
-	cmd := exec.Command(args[0], args[1:]...)
-	stderr, err := cmd.StderrPipe()
+}
+
+// mountTarget mounts the configured filesystems under the target,
+// parents before children, including those of the layout and after
+// a restart when resuming.  Filesystems that are already mounted,
+// such as ones mounted by hand, are left alone.
+func (i *Installer) mountTarget(ctx context.Context) error {
+	log.Println("Mounting target filesystems")
+	mounted, err := mountedPaths()
 	if err != nil {
-		log.Printf("could not get stderr pipe: %v", err)
 		i.Errors <- err
 		return err
 	}
-	stdout, err := cmd.StdoutPipe()
-	if err != nil {
-		log.Printf("could not get stdout pipe: %v", err)
-		i.Errors <- err
-		return err
+
+	fs := []config.Filesystem{}
+	for _, f := range i.Config.Filesystems {
+		if strings.HasPrefix(f.MountTo, "/") {
+			fs = append(fs, f)
+		}
 	}
+	sort.Slice(fs, func(a, b int) bool {
+		return len(filepath.Clean(fs[a].MountTo)) < len(filepath.Clean(fs[b].MountTo))
+	})
 
-	go func() {
-		scanner := bufio.NewScanner(stderr)
-		for scanner.Scan() {
-			msg := scanner.Text()
-			i.Output <- msg
-			log.Println(msg)
-		}
-	}()
-	go func() {
-		scanner := bufio.NewScanner(stdout)
-		for scanner.Scan() {
-			msg := scanner.Text()
-			i.Output <- msg
-			log.Println(msg)
-		}
-	}()
-	log.Printf("$ %s", cmdstr)
-	if err := cmd.Run(); err != nil {
-		log.Printf("could not run cmd: %v", err)
-		i.Errors <- err
-		return err
+	for _, f := range fs {
+		dir := filepath.Join(i.target, f.MountTo)
+		if mounted[dir] {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			i.Errors <- err
+			return err
+		}
+		args := []string{"mount", "-t", f.Type}
+		if f.Options != "" && f.Options != "defaults" {
+			args = append(args, "-o", f.Options)
+		}
+		if err := i.runCommand(ctx, append(args, f.FS, dir)...); err != nil {
+			return err
+		}
+		i.addCleanup("unmount "+dir, func() error {
+			return i.runCommand(context.Background(), "umount", dir)
+		})
 	}
+	return nil
+}
+
+// mountedPaths returns the set of paths that are currently mount
+// points on the live system.
+func mountedPaths() (map[string]bool, error) {
+	b, err := ioutil.ReadFile("/proc/mounts")
 	if err != nil {
-		i.Errors <- err
-		log.Printf("could not wait for cmd: %v", err)
-		return err
+		return nil, err
+	}
+	mounted := make(map[string]bool)
+	for _, l := range strings.Split(string(b), "\n") {
+		fields := strings.Fields(l)
+		if len(fields) > 1 {
+			mounted[fields[1]] = true
+		}
+	}
+	return mounted, nil
+}
+
+func (i *Installer) mountSpecials(ctx context.Context) error {
+	log.Println("Mounting special filesystems")
+	for _, s := range specials {
+		dir := filepath.Join(i.target, s)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			i.Errors <- err
+			return err
+		}
+		if err := i.runCommand(ctx, "mount", "--rbind", "/"+s, dir); err != nil {
+			return err
+		}
+		i.addCleanup("unmount "+dir, func() error {
+			return i.runCommand(context.Background(), "umount", "-R", dir)
+		})
 	}
 	return nil
 }
 
-// Install attempts to put a system on disk
-func (i *Installer) Install(target string) {
+func (i *Installer) addCleanup(desc string, fn func() error) {
+	i.cleanups = append(i.cleanups, cleanup{desc, fn})
+}
+
+// runCleanups runs every registered cleanup, even if some of them
+// fail, and returns the descriptions of those that did.
+func (i *Installer) runCleanups() []string {
+	log.Println("Cleaning up")
+	failed := []string{}
+	for n := len(i.cleanups) - 1; n >= 0; n-- {
+		c := i.cleanups[n]
+		log.Printf("Cleanup: %s", c.desc)
+		if err := c.fn(); err != nil {
+			log.Printf("Cleanup %s failed: %v", c.desc, err)
+			failed = append(failed, c.desc)
+		}
+	}
+	i.cleanups = nil
+	return failed
+}
+
+// Install attempts to put a system on disk.  Cancelling ctx stops
+// the installation after the running command has been killed.
+// Either way the special filesystems are unmounted and the state the
+// target was left in is reported before the channels are closed.
+func (i *Installer) Install(ctx context.Context, target string) {
 	defer i.closeChannels()
 	var err error
 	i.target, err = filepath.Abs(target)
@@ -103,46 +254,141 @@ func (i *Installer) Install(target string) {
 	if i.Meta == nil {
 		i.Meta = config.DefaultMeta()
 	}
-
-	mountSpecials()
-	defer unmountSpecials()
-
-	if err := i.verifyTargetDir(); err != nil {
-		log.Fatal(err)
+	if i.System == nil {
+		i.System = sysinfo.DiscoverHardware()
 	}
+	i.collectSecrets()
 
-	if err := i.installBaseSystem(); err != nil {
-		log.Println(err)
+	if i.DryRun {
+		if err := i.showDisks(); err != nil {
+			return
+		}
+		i.Done <- true
 		return
 	}
-	if err := i.configureHostname(); err != nil {
+
+	if err := i.verifyTargetDir(); err != nil {
 		log.Println(err)
 		return
 	}
-	if err := i.configureRCconf(); err != nil {
-		log.Println(err)
-		return
+
+	err = i.runSteps(ctx)
+	restored := []string{}
+	if err == nil {
+		// The journal and backups are only useful for
+		// resuming or rolling back, once everything is done
+		// they can go.
+		if err := i.removeJournal(); err != nil {
+			log.Printf("Could not remove journal: %v", err)
+		}
+		if err := os.RemoveAll(filepath.Join(i.target, backupDir)); err != nil {
+			log.Printf("Could not remove backups: %v", err)
+		}
+	} else if i.Rollback {
+		restored = i.rollback()
 	}
-	if err := i.configureLocaleconf(); err != nil {
-		log.Println(err)
+	failed := i.runCleanups()
+	i.reportState(ctx, err, restored, failed)
+	if err != nil {
 		return
 	}
-	if err := i.configureFStab(); err != nil {
-		log.Println(err)
-		return
+
+	log.Println("System installed")
+
+	i.Done <- true
+}
+
+func (i *Installer) runSteps(ctx context.Context) error {
+	i.current = "disks"
+	if err := i.prepareDisks(ctx); err != nil {
+		return err
 	}
-	if err := i.addUsers(); err != nil {
-		log.Println(err)
-		return
+	i.current = "mount"
+	if err := i.mountTarget(ctx); err != nil {
+		return err
 	}
-	if err := i.enableServices(); err != nil {
-		log.Println(err)
-		return
+	if err := i.mountSpecials(ctx); err != nil {
+		return err
 	}
+	return i.run(ctx, i.steps())
+}
 
-	log.Println("System installed")
+// run runs steps in order, skipping those the journal says have
+// completed when resuming.
+func (i *Installer) run(ctx context.Context, steps []step) error {
+	start := 0
+	i.journal = new(journal)
+	if i.Resume {
+		var err error
+		i.current = "journal"
+		start, err = i.resumePoint(steps)
+		if err != nil {
+			i.Errors <- err
+			return err
+		}
+		for _, s := range steps[:start] {
+			i.completed = append(i.completed, s.name)
+		}
+		i.restoreUndo()
+		if start > 0 {
+			i.Output <- i18n.M("installer.resuming", steps[start-1].name)
+		}
+	} else if err := i.removeJournal(); err != nil {
+		// A stale journal from an earlier attempt must not
+		// be mistaken for this one.
+		i.Errors <- err
+		return err
+	}
 
-	i.Done <- true
+	for n, s := range steps[start:] {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		i.Output <- progressMessage(start+n+1, len(steps), s.name)
+		i.current = s.name
+		log.Printf("Starting step %s", s.name)
+		if err := s.fn(ctx); err != nil {
+			log.Println(err)
+			return err
+		}
+		if err := i.recordStep(s); err != nil {
+			i.Errors <- err
+			return err
+		}
+		i.completed = append(i.completed, s.name)
+	}
+	i.current = ""
+	return nil
+}
+
+// reportState tells the user what has been done to the target, which
+// matters most when the installation did not run to completion.
+func (i *Installer) reportState(ctx context.Context, err error, restored, failedCleanups []string) {
+	switch {
+	case err == nil:
+		i.Output <- i18n.M("installer.completed", i.target)
+	case ctx.Err() != nil:
+		i.Output <- i18n.M("installer.cancelled", i.current)
+	default:
+		i.Output <- i18n.M("installer.failed", i.current, err)
+	}
+	if err != nil {
+		if len(i.completed) == 0 {
+			i.Output <- i18n.M("installer.no-steps", i.target)
+		} else {
+			i.Output <- i18n.M("installer.completed-steps", strings.Join(i.completed, ", "))
+			i.Output <- i18n.M("installer.partial", i.target)
+		}
+	}
+	if len(restored) > 0 {
+		i.Output <- i18n.M("installer.rolled-back")
+		for _, r := range restored {
+			i.Output <- i18n.Text("    " + r)
+		}
+	}
+	for _, f := range failedCleanups {
+		i.Output <- i18n.M("installer.cleanup-failed", f)
+	}
 }
 
 func (i *Installer) closeChannels() {
@@ -160,15 +406,45 @@ func (i *Installer) verifyTargetDir() error {
 	return nil
 }
 
-func (i *Installer) installBaseSystem() error {
-	return i.xbpsInstall([]string{"base-system"})
+func (i *Installer) installBaseSystem(ctx context.Context) error {
+	return i.xbpsInstall(ctx, []string{"base-system"})
+}
+
+func (i *Installer) installPackages(ctx context.Context) error {
+	pkgs := i.packages()
+	if len(pkgs) == 0 {
+		return nil
+	}
+	i.Output <- i18n.M("installer.packages")
+	return i.xbpsInstall(ctx, pkgs)
+}
+
+// packages returns the packages to install in addition to
+// base-system, which are those configured and any that other parts
+// of the configuration need.
+func (i *Installer) packages() []string {
+	pkgs := append([]string{}, i.Config.Packages...)
+	if i.Config.PrivilegeTool() == "doas" && !contains(pkgs, "opendoas") {
+		pkgs = append(pkgs, "opendoas")
+	}
+	if i.Config.NetworkManager() == "NetworkManager" && !contains(pkgs, "NetworkManager") {
+		pkgs = append(pkgs, "NetworkManager")
+	}
+	if i.plan != nil {
+		for _, p := range i.plan.Packages {
+			if !contains(pkgs, p) {
+				pkgs = append(pkgs, p)
+			}
+		}
+	}
+	return pkgs
 }
 
-func (i *Installer) xbpsInstall(pkgs []string) error {
+func (i *Installer) xbpsInstall(ctx context.Context, pkgs []string) error {
 	baseDir := filepath.Join(i.target, "var/db/xbps/")
 
 	if _, err := os.Stat(baseDir); os.IsNotExist(err) {
-		i.Output <- "Installing keys"
+		i.Output <- i18n.M("installer.keys")
 		if err := os.MkdirAll(baseDir, 0755); err != nil {
 			i.Errors <- err
 			return err
@@ -180,200 +456,231 @@ func (i *Installer) xbpsInstall(pkgs []string) error {
 		}
 	}
 
-	cmd := fmt.Sprintf("xbps-install -y -S -i -R %s -M -r %s %s",
-		i.Meta.Mirror,
-		i.target,
-		strings.Join(pkgs, " "),
-	)
+	args := []string{"xbps-install", "-y", "-S", "-i", "-R", i.Meta.Mirror, "-M", "-r", i.target}
 
 	// We drop the error here because xbps-install can return
-	// non-zero in places where things should otherwise be fine.
-	i.runCommand(cmd)
-	return nil
+	// non-zero in places where things should otherwise be fine,
+	// but a cancellation still has to stop the installation.
+	i.runCommand(ctx, append(args, pkgs...)...)
+	return ctx.Err()
 }
 
-func (i *Installer) configureHostname() error {
+func (i *Installer) configureHostname(ctx context.Context) error {
 	// Write the hosts file out
-	i.Output <- "Configuring network names"
-	i.Output <- "  Configuring /etc/hosts"
+	i.Output <- i18n.M("installer.names")
+	i.Output <- i18n.M("installer.configuring", "/etc/hosts")
 	log.Println("Configuring /etc/hosts")
-	t, err := fetchTemplate("hosts")
-	if err != nil {
-		i.Errors <- err
-		return err
-	}
-	f, err := os.OpenFile(filepath.Join(i.target, "etc/hosts"), os.O_RDWR|os.O_CREATE, 0644)
-	if err != nil {
-		i.Errors <- err
-		return err
-	}
-	if err := t.Execute(f, i.Config.Hostname); err != nil {
-		i.Errors <- err
+	if err := i.writeTemplate("hosts", "etc/hosts", 0644); err != nil {
 		return err
 	}
-	if err := f.Close(); err != nil {
-		i.Errors <- err
-		return err
-	}
-	i.Output <- "    /etc/hosts has been configured"
+	i.Output <- i18n.M("installer.configured", "/etc/hosts")
 
-	// Set the hostname
-	i.Output <- "  Configuring /etc/hostname"
+	// Set the hostname, which is only ever the short name.
+	if i.Config.Hostname == "" {
+		return nil
+	}
+	i.Output <- i18n.M("installer.configuring", "/etc/hostname")
 	log.Println("Configuring /etc/hostname")
-	hostname := []byte(strings.Split(i.Config.Hostname, ".")[0])
-	if err := ioutil.WriteFile(filepath.Join(i.target, "etc/hostname"), hostname, 0644); err != nil {
-		i.Errors <- err
+	hostname := []byte(i.Config.ShortHostname() + "\n")
+	if err := i.writeFile("etc/hostname", hostname, 0644); err != nil {
 		return err
 	}
-	i.Output <- "    /etc/hostname has been configured"
+	i.Output <- i18n.M("installer.configured", "/etc/hostname")
 
 	return nil
 }
 
-func (i *Installer) configureRCconf() error {
-	// Write the hosts file out
-	i.Output <- "Configuring /etc/rc.conf"
-	log.Println("Configuring /etc/rc.conf")
-	t, err := fetchTemplate("rc.conf")
+// configureTimeZone points /etc/localtime at the configured zone.
+// The zone is checked against the target's database, which is what
+// will be used at runtime, unless the target doesn't have one.
+func (i *Installer) configureTimeZone(ctx context.Context) error {
+	if i.Config.TimeZone == "" {
+		return nil
+	}
+	i.Output <- i18n.M("installer.configuring", "/etc/localtime")
+	log.Println("Configuring /etc/localtime")
+
+	root := i.target
+	if !timezone.Available(root) {
+		root = "/"
+	}
+	zones, err := timezone.List(root)
 	if err != nil {
 		i.Errors <- err
 		return err
 	}
-	f, err := os.OpenFile(filepath.Join(i.target, "etc/rc.conf"), os.O_RDWR|os.O_CREATE, 0644)
+	zone, err := timezone.Canonicalize(zones, i.Config.TimeZone)
 	if err != nil {
+		err = fmt.Errorf("%s: %v", i.Config.TimeZone, err)
 		i.Errors <- err
 		return err
 	}
-	data := struct {
-		TimeZone string
-		Keyboard string
-	}{
-		TimeZone: i.Config.TimeZone,
-		Keyboard: i.Config.Keyboard,
+	if err := i.backupFile("etc/localtime"); err != nil {
+		return err
 	}
-
-	if err := t.Execute(f, data); err != nil {
+	localtime := filepath.Join(i.target, "etc/localtime")
+	if err := os.Remove(localtime); err != nil && !os.IsNotExist(err) {
 		i.Errors <- err
 		return err
 	}
-	if err := f.Close(); err != nil {
+	if err := os.Symlink(filepath.Join("/", timezone.Dir, zone), localtime); err != nil {
 		i.Errors <- err
 		return err
 	}
-	i.Output <- "  /etc/rc.conf has been configured"
+	i.Output <- i18n.M("installer.localtime", zone)
 	return nil
 }
 
-func (i *Installer) configureLocaleconf() error {
-	// Write the hosts file out
-	i.Output <- "Configuring /etc/locale.conf"
-	log.Println("Configuring /etc/locale.conf")
-	t, err := fetchTemplate("locale.conf")
-	if err != nil {
-		i.Errors <- err
+func (i *Installer) configureRCconf(ctx context.Context) error {
+	i.Output <- i18n.M("installer.configuring", "/etc/rc.conf")
+	log.Println("Configuring /etc/rc.conf")
+	if err := i.writeTemplate("rc.conf", "etc/rc.conf", 0644); err != nil {
 		return err
 	}
-	f, err := os.OpenFile(filepath.Join(i.target, "etc/locale.conf"), os.O_RDWR|os.O_CREATE, 0644)
-	if err != nil {
-		i.Errors <- err
+	i.Output <- i18n.M("installer.configured", "/etc/rc.conf")
+	return nil
+}
+
+func (i *Installer) configureLocaleconf(ctx context.Context) error {
+	i.Output <- i18n.M("installer.configuring", "/etc/locale.conf")
+	log.Println("Configuring /etc/locale.conf")
+	if err := i.writeTemplate("locale.conf", "etc/locale.conf", 0644); err != nil {
 		return err
 	}
-	if err := t.Execute(f, i.Config.Locale); err != nil {
+	i.Output <- i18n.M("installer.configured", "/etc/locale.conf")
+	return nil
+}
+
+// configureKeyboard sets the X11 keyboard layout to match the
+// console keymap, which only matters if there is an X server.
+func (i *Installer) configureKeyboard(ctx context.Context) error {
+	if i.Config.Keyboard == "" || !keyboard.Graphical(i.Config.Packages) {
+		return nil
+	}
+	path := "etc/X11/xorg.conf.d/00-keyboard.conf"
+	i.Output <- i18n.M("installer.configuring", "/"+path)
+	log.Println("Configuring /" + path)
+	if _, ok := keyboard.ToXKB(i.Config.Keyboard); !ok {
+		i.Output <- i18n.M("installer.xkb-guess", i.Config.Keyboard)
+	}
+	if err := os.MkdirAll(filepath.Join(i.target, filepath.Dir(path)), 0755); err != nil {
 		i.Errors <- err
 		return err
 	}
-	if err := f.Close(); err != nil {
-		i.Errors <- err
+	if err := i.writeTemplate("00-keyboard.conf", path, 0644); err != nil {
 		return err
 	}
-	i.Output <- "  /etc/locale.conf has been configured"
+	i.Output <- i18n.M("installer.configured", "/"+path)
 	return nil
 }
 
-func (i *Installer) configureFStab() error {
-	// Write the hosts file out
-	i.Output <- "Configuring /etc/fstab"
+func (i *Installer) configureFStab(ctx context.Context) error {
+	i.Output <- i18n.M("installer.configuring", "/etc/fstab")
 	log.Println("Configuring /etc/fstab")
-	t, err := fetchTemplate("fstab")
-	if err != nil {
-		i.Errors <- err
+	if err := i.writeTemplate("fstab", "etc/fstab", 0644); err != nil {
 		return err
 	}
-	f, err := os.OpenFile(filepath.Join(i.target, "etc/fstab"), os.O_RDWR|os.O_CREATE, 0644)
-	if err != nil {
-		i.Errors <- err
-		return err
+	i.Output <- i18n.M("installer.configured", "/etc/fstab")
+	return nil
+}
+
+// accountFiles are changed by useradd and chpasswd, they are saved
+// before any users are added so that they can be restored.
+var accountFiles = []string{"etc/passwd", "etc/shadow", "etc/group", "etc/gshadow"}
+
+func (i *Installer) addUsers(ctx context.Context) error {
+	i.Output <- i18n.M("installer.users")
+	log.Println("Adding user accounts")
+
+	for _, f := range accountFiles {
+		if err := i.backupFile(f); err != nil {
+			return err
+		}
 	}
-	if err := t.Execute(f, i.Config.Filesystems); err != nil {
-		i.Errors <- err
-		return err
+
+	for _, u := range i.Config.Users {
+		if err := i.backupFile(homeDir(u)); err != nil {
+			return err
+		}
+
+		if err := i.addUser(ctx, u); err != nil {
+			return err
+		}
+		if err := i.setPassword(ctx, u.Username, u.Password, u.PasswordHash); err != nil {
+			return err
+		}
+		if u.Lock {
+			if err := i.chroot(ctx, "passwd", "-l", u.Username); err != nil {
+				return err
+			}
+			i.Output <- i18n.M("installer.locked", u.Username)
+		}
+		if err := i.installSSHKeys(u); err != nil {
+			return err
+		}
 	}
-	if err := f.Close(); err != nil {
-		i.Errors <- err
+	if err := ctx.Err(); err != nil {
 		return err
 	}
-	i.Output <- "  /etc/locale.conf has been configured"
+
+	i.Output <- i18n.M("installer.users-added")
 	return nil
 }
 
-func (i *Installer) enableServices() error {
-	i.Output <- "Enabling Services"
-	serviceDir := filepath.Join(i.target, "etc/runit/runsvdir/default/")
-	for _, s := range i.Meta.Services {
-		i.Output <- fmt.Sprintf("  %s", s)
-		if err := os.Symlink(filepath.Join("/etc/sv/", s), filepath.Join(serviceDir, s)); err != nil {
-			i.Errors <- err
+// configureRoot sets up the root account.  Locking root is refused
+// unless a wheel user exists, as nobody could administer the system
+// otherwise.
+func (i *Installer) configureRoot(ctx context.Context) error {
+	i.Output <- i18n.M("installer.root")
+	log.Println("Configuring the root account")
+	for _, f := range accountFiles {
+		if err := i.backupFile(f); err != nil {
 			return err
 		}
 	}
-	return nil
-}
 
-func (i *Installer) addUsers() error {
-	i.Output <- "Adding user account(s)"
-	log.Println("Adding user accounts")
+	if err := i.setPassword(ctx, "root", i.Config.RootPassword, i.Config.RootPasswordHash); err != nil {
+		return err
+	}
 
-	for _, u := range i.Config.Users {
-		cmd := fmt.Sprintf("chroot %s useradd -m -U -G %s -c '%s' %s",
-			i.target,
-			strings.Join(u.Groups, ","),
-			u.GECOS,
-			u.Username,
-		)
-		i.runCommand(cmd)
-
-		cmd = fmt.Sprintf("sh -c 'echo %s:%s | chroot %s chpasswd -c SHA512'",
-			u.Username,
-			u.Password,
-			i.target,
-		)
-		i.runCommand(cmd)
-	}
-
-	i.Output <- "  User accounts added"
-	if len(i.Config.Users) > 0 {
-		return i.configureSudo()
+	if i.Config.LockRoot {
+		if !i.Config.HasWheelUser() {
+			i.Output <- i18n.M("installer.root-not-locked")
+		} else {
+			if err := i.chroot(ctx, "passwd", "-l", "root"); err != nil {
+				return err
+			}
+			i.Output <- i18n.M("installer.locked", "root")
+		}
+	}
+
+	if i.Config.RootShell != "" {
+		if err := i.chroot(ctx, "usermod", "-s", i.Config.RootShell, "root"); err != nil {
+			return err
+		}
+		i.Output <- i18n.M("installer.root-shell", i.Config.RootShell)
 	}
 	return nil
 }
 
-func (i *Installer) configureSudo() error {
-	i.Output <- "Configuring /etc/sudoers.d/wheel"
-	log.Println("Configuring /etc/sudoers.d/wheel")
-	config := []byte("%wheel ALL=(ALL) ALL")
-	if err := ioutil.WriteFile(filepath.Join(i.target, "etc/sudoers.d/wheel"), config, 0644); err != nil {
+// writeFile replaces a file in the target, which path is relative
+// to, after backing it up.
+func (i *Installer) writeFile(path string, data []byte, mode os.FileMode) error {
+	if err := i.backupFile(path); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(i.target, path), data, mode); err != nil {
 		i.Errors <- err
 		return err
 	}
-	i.Output <- "  /etc/sudoers.d/wheel has been configured"
 	return nil
 }
 
-func fetchTemplate(name string) (*template.Template, error) {
-	templateString, err := Asset("templates/" + name)
-	if err != nil {
-		log.Fatal(err)
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
 	}
-
-	return template.New(name).Parse(string(templateString))
+	return false
 }
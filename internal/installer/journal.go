@@ -0,0 +1,167 @@
+package installer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+)
+
+// journalPath is where the record of completed steps is kept,
+// relative to the root of the target.
+const journalPath = "var/lib/vInstaller/journal.json"
+
+var (
+	// ErrNoJournal is returned when resuming an installation
+	// into a target that does not contain a journal.
+	ErrNoJournal = errors.New("no installation journal found in target")
+
+	// ErrJournalMismatch is returned when the journal in the
+	// target was written with a configuration that differs from
+	// the current one.
+	ErrJournalMismatch = errors.New("installation journal does not match the configuration")
+)
+
+// journal records the steps that have completed in the target so
+// that an interrupted installation can pick up where it left off,
+// and the files they backed up so that it can still be rolled back.
+type journal struct {
+	Entries []journalEntry `json:"entries"`
+	Backups []backup       `json:"backups,omitempty"`
+}
+
+// A journalEntry is a completed step along with a hash of the
+// configuration that went into it.
+type journalEntry struct {
+	Step      string    `json:"step"`
+	Hash      string    `json:"hash"`
+	Completed time.Time `json:"completed"`
+}
+
+// hashInputs returns a stable hash of the configuration a step
+// depends on, which must not include secrets, see hideSecret.
+func hashInputs(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// hideSecret stands in for a secret in the inputs of a step.  The
+// journal stays behind in the installed system, where a hash of a
+// password is as good as the password to anyone with a wordlist, so
+// only whether there is a secret is recorded.
+func hideSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "********"
+}
+
+// usersInputs returns the users without their passwords.
+func usersInputs(users []config.User) []config.User {
+	out := append([]config.User{}, users...)
+	for n := range out {
+		out[n].Password = hideSecret(out[n].Password)
+	}
+	return out
+}
+
+// networkInputs returns the network configuration without the keys
+// of wireless networks.
+func networkInputs(net config.Network) config.Network {
+	net.Wifi = append([]config.Wifi{}, net.Wifi...)
+	for n := range net.Wifi {
+		net.Wifi[n].PSK = hideSecret(net.Wifi[n].PSK)
+	}
+	return net
+}
+
+func (i *Installer) loadJournal() (*journal, error) {
+	b, err := ioutil.ReadFile(filepath.Join(i.target, journalPath))
+	if os.IsNotExist(err) {
+		return nil, ErrNoJournal
+	}
+	if err != nil {
+		return nil, err
+	}
+	j := new(journal)
+	if err := json.Unmarshal(b, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// saveJournal writes the journal out atomically so that a crash
+// while writing it doesn't lose the steps already recorded.
+func (i *Installer) saveJournal() error {
+	path := filepath.Join(i.target, journalPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(i.journal, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path+".tmp", b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (i *Installer) removeJournal() error {
+	err := os.Remove(filepath.Join(i.target, journalPath))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// recordStep adds a completed step to the journal.
+func (i *Installer) recordStep(s step) error {
+	hash, err := hashInputs(s.inputs)
+	if err != nil {
+		return err
+	}
+	i.journal.Entries = append(i.journal.Entries, journalEntry{
+		Step:      s.name,
+		Hash:      hash,
+		Completed: time.Now(),
+	})
+	return i.saveJournal()
+}
+
+// resumePoint checks the journal in the target against the steps
+// that would be run with the current configuration, and returns the
+// index of the first step that has not yet been completed.
+func (i *Installer) resumePoint(steps []step) (int, error) {
+	j, err := i.loadJournal()
+	if err != nil {
+		return 0, err
+	}
+	if len(j.Entries) > len(steps) {
+		return 0, ErrJournalMismatch
+	}
+	for n, e := range j.Entries {
+		hash, err := hashInputs(steps[n].inputs)
+		if err != nil {
+			return 0, err
+		}
+		if e.Step != steps[n].name || e.Hash != hash {
+			log.Printf("Journal entry %d (%s) does not match step %s", n, e.Step, steps[n].name)
+			return 0, fmt.Errorf("%v: step %s", ErrJournalMismatch, e.Step)
+		}
+	}
+	i.journal = j
+	return len(j.Entries), nil
+}
@@ -0,0 +1,62 @@
+package installer
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// configureNetwork writes the configuration for dhcpcd, the name
+// servers and wpa_supplicant.  Nothing is written if the defaults
+// will do, and nothing at all for NetworkManager, which only needs
+// its services enabled.
+func (i *Installer) configureNetwork(ctx context.Context) error {
+	n := i.Config.Network
+	if i.Config.NetworkManager() != "dhcpcd" {
+		return nil
+	}
+	if len(n.Interfaces) == 0 && len(n.DNS) == 0 && len(n.Wifi) == 0 {
+		return nil
+	}
+	i.Output <- i18n.M("installer.network")
+	log.Println("Configuring the network")
+
+	if len(n.Interfaces) > 0 || len(n.DNS) > 0 {
+		if err := i.writeTemplate("dhcpcd.conf", "etc/dhcpcd.conf", 0644); err != nil {
+			return err
+		}
+		i.Output <- i18n.M("installer.configured", "/etc/dhcpcd.conf")
+	}
+
+	if len(n.DNS) > 0 {
+		// resolv.conf may be a link to a file managed by
+		// resolvconf, which must not be written through.
+		if err := i.backupFile("etc/resolv.conf"); err != nil {
+			return err
+		}
+		if err := os.Remove(filepath.Join(i.target, "etc/resolv.conf")); err != nil && !os.IsNotExist(err) {
+			i.Errors <- err
+			return err
+		}
+		if err := i.writeTemplate("resolv.conf", "etc/resolv.conf", 0644); err != nil {
+			return err
+		}
+		i.Output <- i18n.M("installer.configured", "/etc/resolv.conf")
+	}
+
+	if len(n.Wifi) > 0 {
+		if err := os.MkdirAll(filepath.Join(i.target, "etc/wpa_supplicant"), 0755); err != nil {
+			i.Errors <- err
+			return err
+		}
+		// The file holds the keys, so only root may read it.
+		if err := i.writeTemplate("wpa_supplicant.conf", "etc/wpa_supplicant/wpa_supplicant.conf", 0600); err != nil {
+			return err
+		}
+		i.Output <- i18n.M("installer.configured", "/etc/wpa_supplicant/wpa_supplicant.conf")
+	}
+	return nil
+}
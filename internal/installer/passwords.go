@@ -0,0 +1,65 @@
+package installer
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/shadow"
+)
+
+// collectSecrets gathers everything from the configuration that must
+// not show up in logs.
+func (i *Installer) collectSecrets() {
+	i.secrets = nil
+	for _, u := range i.Config.Users {
+		i.addSecret(u.Password)
+		i.addSecret(u.PasswordHash)
+	}
+	i.addSecret(i.Config.RootPassword)
+	i.addSecret(i.Config.RootPasswordHash)
+	for _, w := range i.Config.Network.Wifi {
+		i.addSecret(w.PSK)
+	}
+	i.addSecret(i.Config.Layout.Passphrase)
+}
+
+func (i *Installer) addSecret(s string) {
+	if s != "" {
+		i.secrets = append(i.secrets, s)
+	}
+}
+
+// redact replaces every occurrence of any secret in s, even inside a
+// longer word, as what surrounds a password says nothing about where
+// it ends.  Longer secrets are replaced first, so that a secret which
+// is part of another doesn't leave the rest of that one behind.
+func (i *Installer) redact(s string) string {
+	secrets := append([]string{}, i.secrets...)
+	sort.Slice(secrets, func(a, b int) bool { return len(secrets[a]) > len(secrets[b]) })
+	for _, secret := range secrets {
+		s = strings.Replace(s, secret, "********", -1)
+	}
+	return s
+}
+
+// setPassword sets the password of a user in the target.  A hash is
+// used as is, otherwise the password is hashed with SHA-512 crypt.
+// Either way only the hash reaches chpasswd, through its standard
+// input.
+func (i *Installer) setPassword(ctx context.Context, user, password, hash string) error {
+	if hash == "" && password == "" {
+		return nil
+	}
+
+	if hash == "" {
+		var err error
+		hash, err = shadow.SHA512(password)
+		if err != nil {
+			i.Errors <- err
+			return err
+		}
+		i.addSecret(hash)
+	}
+	return i.chrootInput(ctx, strings.NewReader(user+":"+hash+"\n"), "chpasswd", "-e")
+}
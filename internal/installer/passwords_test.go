@@ -0,0 +1,19 @@
+package installer
+
+import "testing"
+
+func TestRedact(t *testing.T) {
+	i := &Installer{secrets: []string{"void", "voidlinux", "s3cret"}}
+	for s, want := range map[string]string{
+		"chpasswd void":          "chpasswd ********",
+		"password=voidlinux":     "password=********",
+		"xvoidx and s3cret_ok":   "x********x and ********_ok",
+		"nothing to hide here":   "nothing to hide here",
+		"s3crets3cret":           "****************",
+		"voidvoidlinux voidlinu": "**************** ********linu",
+	} {
+		if got := i.redact(s); got != want {
+			t.Errorf("redact(%q) = %q, want %q", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// privilegeFiles are where the policy for each tool is written, and
+// the command that checks a policy file for errors.
+var privilegeFiles = map[string]struct {
+	template string
+	path     string
+	check    []string
+}{
+	"sudo": {"sudoers", "etc/sudoers.d/wheel", []string{"visudo", "-c", "-f"}},
+	"doas": {"doas.conf", "etc/doas.conf", []string{"doas", "-C"}},
+}
+
+// configurePrivilege writes the policy that lets users become root.
+// The policy is checked by the tool itself inside the target before
+// it is put in place, as a broken policy would lock everyone out.
+func (i *Installer) configurePrivilege(ctx context.Context) error {
+	if !i.Config.HasWheelUser() && len(i.Config.Privilege.Rules) == 0 {
+		return nil
+	}
+	tool := i.Config.PrivilegeTool()
+	pf, ok := privilegeFiles[tool]
+	if !ok {
+		err := fmt.Errorf("unknown privilege tool %s", tool)
+		i.Errors <- err
+		return err
+	}
+	i.Output <- i18n.M("installer.configuring", "/"+pf.path)
+	log.Printf("Configuring /%s", pf.path)
+
+	dir := filepath.Dir(pf.path)
+	if err := i.backupFile(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(i.target, dir), 0750); err != nil {
+		i.Errors <- err
+		return err
+	}
+
+	// sudo skips files in sudoers.d with a dot in their name, so
+	// the unchecked policy can't take effect by accident.
+	tmp := filepath.Join(dir, "."+filepath.Base(pf.path)+".new")
+	if err := i.renderTemplate(pf.template, tmp, 0440); err != nil {
+		return err
+	}
+	check := append(append([]string{}, pf.check...), "/"+tmp)
+	if err := i.chroot(ctx, check...); err != nil {
+		os.Remove(filepath.Join(i.target, tmp))
+		return err
+	}
+
+	if err := i.backupFile(pf.path); err != nil {
+		return err
+	}
+	if err := os.Rename(filepath.Join(i.target, tmp), filepath.Join(i.target, pf.path)); err != nil {
+		i.Errors <- err
+		return err
+	}
+	i.Output <- i18n.M("installer.configured", "/"+pf.path)
+	return nil
+}
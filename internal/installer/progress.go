@@ -0,0 +1,34 @@
+package installer
+
+import (
+	"strconv"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// progressID identifies the message sent as each step starts.
+const progressID = "installer.step"
+
+// progressMessage returns the message sent as step n of total, which
+// counts from 1, starts.
+func progressMessage(n, total int, name string) i18n.Message {
+	return i18n.M(progressID, n, total, name)
+}
+
+// ParseProgress recognises the message the installer sends as each
+// step starts, so that a frontend can show how far along the
+// installation is.  ok is false for any other output.
+func ParseProgress(msg i18n.Message) (n, total int, name string, ok bool) {
+	if msg.ID != progressID || len(msg.Args) != 3 {
+		return 0, 0, "", false
+	}
+	n, err := strconv.Atoi(msg.Args[0])
+	if err != nil {
+		return 0, 0, "", false
+	}
+	total, err = strconv.Atoi(msg.Args[1])
+	if err != nil || n < 1 || n > total {
+		return 0, 0, "", false
+	}
+	return n, total, msg.Args[2], true
+}
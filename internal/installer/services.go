@@ -0,0 +1,161 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+const (
+	// svDir holds the services packages install, relative to the
+	// root of the target.
+	svDir = "etc/sv"
+
+	// runsvdirDir holds a directory of enabled services for each
+	// runlevel.
+	runsvdirDir = "etc/runit/runsvdir"
+)
+
+// services returns the services to configure, which are those from
+// the metadata adjusted to suit the network configuration, followed
+// by those from the configuration.  A configured service replaces a
+// default one of the same name in the same runsvdir.
+func (i *Installer) services() []config.Service {
+	nm := i.Config.NetworkManager() == "NetworkManager"
+	names := []string{}
+	for _, s := range i.Meta.Services {
+		// NetworkManager runs its own DHCP client.
+		if nm && s == "dhcpcd" {
+			continue
+		}
+		names = append(names, s)
+	}
+	switch {
+	case nm:
+		names = append(names, "dbus", "NetworkManager")
+	case len(i.Config.Network.Wifi) > 0:
+		names = append(names, "dhcpcd", "wpa_supplicant")
+	case len(i.Config.Network.Interfaces) > 0:
+		names = append(names, "dhcpcd")
+	}
+
+	svcs := []config.Service{}
+	seen := make(map[string]int)
+	add := func(s config.Service) {
+		if s.Runsvdir == "" {
+			s.Runsvdir = "default"
+		}
+		key := s.Runsvdir + "/" + s.Name
+		if n, ok := seen[key]; ok {
+			svcs[n] = s
+			return
+		}
+		seen[key] = len(svcs)
+		svcs = append(svcs, s)
+	}
+	for _, n := range names {
+		add(config.Service{Name: n})
+	}
+	for _, s := range i.Config.Services {
+		add(s)
+	}
+	return svcs
+}
+
+// enableServices links services into their runsvdirs, or removes
+// them for those that are disabled.  Links that are already in place
+// are left alone so that the step can be run again.
+func (i *Installer) enableServices(ctx context.Context) error {
+	i.Output <- i18n.M("installer.services")
+	log.Println("Enabling services")
+	for _, s := range i.services() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := i.configureService(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *Installer) configureService(s config.Service) error {
+	dir := filepath.Join(runsvdirDir, s.Runsvdir)
+	link := filepath.Join(dir, s.Name)
+	dest := filepath.Join("/", svDir, s.Name)
+
+	if s.Disable {
+		if _, err := os.Lstat(filepath.Join(i.target, link)); os.IsNotExist(err) {
+			return nil
+		}
+		if err := i.backupFile(link); err != nil {
+			return err
+		}
+		if err := os.RemoveAll(filepath.Join(i.target, link)); err != nil {
+			i.Errors <- err
+			return err
+		}
+		i.Output <- i18n.M("installer.service-disabled", s.Name, s.Runsvdir)
+		return nil
+	}
+
+	if info, err := os.Stat(filepath.Join(i.target, dest)); err != nil || !info.IsDir() {
+		err := fmt.Errorf("service %s is not installed in the target", s.Name)
+		i.Errors <- err
+		return err
+	}
+
+	if err := i.backupFile(dir); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(i.target, dir), 0755); err != nil {
+		i.Errors <- err
+		return err
+	}
+
+	// A down file left from an earlier run or shipped by the
+	// package would keep the service from starting.
+	down := filepath.Join(svDir, s.Name, "down")
+	_, err := os.Lstat(filepath.Join(i.target, down))
+	switch {
+	case s.Down && os.IsNotExist(err):
+		if err := i.writeFile(down, nil, 0644); err != nil {
+			return err
+		}
+	case !s.Down && err == nil:
+		if err := i.backupFile(down); err != nil {
+			return err
+		}
+		if err := os.Remove(filepath.Join(i.target, down)); err != nil {
+			i.Errors <- err
+			return err
+		}
+	}
+
+	if current, err := os.Readlink(filepath.Join(i.target, link)); err == nil && current == dest {
+		i.Output <- i18n.M("installer.service-already-enabled", s.Name, s.Runsvdir)
+		return nil
+	}
+	if err := i.backupFile(link); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(i.target, link)); err != nil {
+		i.Errors <- err
+		return err
+	}
+	if err := os.Symlink(dest, filepath.Join(i.target, link)); err != nil {
+		i.Errors <- err
+		return err
+	}
+	if s.Down {
+		i.Output <- i18n.M("installer.service-down", s.Name, s.Runsvdir)
+	} else {
+		i.Output <- i18n.M("installer.service-enabled", s.Name, s.Runsvdir)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/layout"
+)
+
+// planLayout works out the layout of the configured disk, if there
+// is one.  A disk given by one of its links in /dev/disk is planned
+// under its kernel name, which its partitions are named after.
+func (i *Installer) planLayout() error {
+	if i.Config.Layout.Disk == "" {
+		return nil
+	}
+	l := i.Config.Layout
+	if dev, err := filepath.EvalSymlinks(l.Disk); err == nil {
+		l.Disk = dev
+	}
+	plan, err := layout.New(l, i.System.EFI())
+	if err != nil {
+		i.Errors <- err
+		return err
+	}
+	i.plan = plan
+	return nil
+}
+
+// prepareDisks lays out the configured disk and formats the
+// filesystems marked to be formatted, then adds the filesystems of
+// the layout to the configuration so that they are mounted and go
+// into fstab.  When resuming nothing is formatted again, the
+// encrypted partition is only opened.
+func (i *Installer) prepareDisks(ctx context.Context) error {
+	if err := i.planLayout(); err != nil {
+		return err
+	}
+
+	if !i.Resume {
+		if i.plan != nil {
+			if err := i.layOut(ctx); err != nil {
+				return err
+			}
+		}
+		for _, f := range i.Config.Filesystems {
+			if !f.Format {
+				continue
+			}
+			i.Output <- i18n.M("installer.formatting", f.FS, f.Type)
+			if err := i.runCommand(ctx, layout.Mkfs(f)...); err != nil {
+				return err
+			}
+		}
+	} else if i.plan != nil && i.plan.CryptDevice != "" {
+		if err := i.openCrypt(ctx); err != nil {
+			return err
+		}
+	}
+
+	if i.plan == nil {
+		return nil
+	}
+	return i.addLayoutFilesystems(ctx)
+}
+
+// showDisks shows what prepareDisks would do to the disks, down to
+// the commands it would run, without running any of them.
+func (i *Installer) showDisks() error {
+	if err := i.planLayout(); err != nil {
+		return err
+	}
+	i.Output <- i18n.M("installer.dry-run")
+	if i.plan != nil {
+		i.describeLayout()
+		for _, c := range i.plan.Commands(filepath.Join(os.TempDir(), "vInstaller")) {
+			i.Output <- i18n.Text("  $ " + i.redact(quoteArgs(c.Args)))
+		}
+	}
+	for _, f := range i.Config.Filesystems {
+		if f.Format {
+			i.Output <- i18n.M("installer.formatting", f.FS, f.Type)
+			i.Output <- i18n.Text("  $ " + i.redact(quoteArgs(layout.Mkfs(f))))
+		}
+	}
+	return nil
+}
+
+func (i *Installer) describeLayout() {
+	i.Output <- i18n.M("installer.erasing", i.plan.Disk, i.plan.Scheme)
+	for _, l := range i.plan.Describe() {
+		i.Output <- i18n.Text("  " + l)
+	}
+}
+
+// layOut partitions and formats the disk of the layout.
+func (i *Installer) layOut(ctx context.Context) error {
+	i.describeLayout()
+
+	workdir, err := ioutil.TempDir("", "vInstaller")
+	if err != nil {
+		i.Errors <- err
+		return err
+	}
+	defer os.Remove(workdir)
+
+	for _, c := range i.plan.Commands(workdir) {
+		if err := i.runCommandInput(ctx, strings.NewReader(c.Input), c.Args...); err != nil {
+			return err
+		}
+		if c.Args[0] == "cryptsetup" && c.Args[1] == "open" {
+			i.addCryptCleanup()
+		}
+	}
+	return nil
+}
+
+// openCrypt opens the encrypted partition of an earlier attempt and
+// activates the volume group inside it.
+func (i *Installer) openCrypt(ctx context.Context) error {
+	if _, err := os.Stat(i.plan.Mapped()); os.IsNotExist(err) {
+		i.Output <- i18n.M("installer.opening", i.plan.CryptDevice)
+		open := i.plan.Open()
+		if err := i.runCommandInput(ctx, strings.NewReader(open.Input), open.Args...); err != nil {
+			return err
+		}
+		i.addCryptCleanup()
+	}
+	return i.runCommand(ctx, "vgchange", "-ay", i.plan.VolumeGroup)
+}
+
+// addCryptCleanup closes the encrypted partition once the
+// installation is over, after the filesystems on it are unmounted.
+func (i *Installer) addCryptCleanup() {
+	i.addCleanup("close "+i.plan.Mapped(), func() error {
+		if err := i.runCommand(context.Background(), "vgchange", "-an", i.plan.VolumeGroup); err != nil {
+			return err
+		}
+		return i.runCommand(context.Background(), "cryptsetup", "close", i.plan.CryptName)
+	})
+}
+
+// addLayoutFilesystems puts the filesystems of the layout in front of
+// the configured ones, by UUID as device names can change between
+// boots.
+func (i *Installer) addLayoutFilesystems(ctx context.Context) error {
+	fs := []config.Filesystem{}
+	for _, f := range i.plan.Filesystems {
+		uuid, err := i.uuid(ctx, f.FS)
+		if err != nil {
+			return err
+		}
+		f.FS = "UUID=" + uuid
+		f.Format = false
+		fs = append(fs, f)
+	}
+	if i.plan.CryptDevice != "" {
+		uuid, err := i.uuid(ctx, i.plan.CryptDevice)
+		if err != nil {
+			return err
+		}
+		i.plan.CryptUUID = uuid
+	}
+	i.Config.Filesystems = append(fs, i.Config.Filesystems...)
+	return nil
+}
+
+func (i *Installer) uuid(ctx context.Context, dev string) (string, error) {
+	uuid, err := i.commandOutput(ctx, "blkid", "-s", "UUID", "-o", "value", dev)
+	if err != nil {
+		return "", err
+	}
+	if uuid == "" {
+		err := fmt.Errorf("%s has no UUID", dev)
+		i.Errors <- err
+		return "", err
+	}
+	return uuid, nil
+}
+
+// configureStorage tells the initramfs how to open the encrypted
+// partition and regenerates it, nothing else needs this.
+func (i *Installer) configureStorage(ctx context.Context) error {
+	if i.plan == nil || i.plan.CryptDevice == "" {
+		return nil
+	}
+	i.Output <- i18n.M("installer.initramfs")
+	log.Println("Configuring /etc/crypttab and dracut")
+	if err := i.writeTemplate("crypttab", "etc/crypttab", 0600); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(i.target, "etc/dracut.conf.d"), 0755); err != nil {
+		i.Errors <- err
+		return err
+	}
+	if err := i.writeTemplate("dracut.conf", "etc/dracut.conf.d/10-crypt.conf", 0644); err != nil {
+		return err
+	}
+	if err := i.chroot(ctx, "dracut", "--force", "--regenerate-all"); err != nil {
+		return err
+	}
+	i.Output <- i18n.M("installer.initramfs-done")
+	return nil
+}
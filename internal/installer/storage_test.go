@@ -0,0 +1,153 @@
+package installer
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// A fakeRun is a command the installer ran while command was faked.
+type fakeRun struct {
+	args  []string
+	input string
+}
+
+// fakeCommands stands in for every command the installer runs.  The
+// commands only read their input, apart from blkid which prints a
+// UUID.  It returns the commands run so far and a function that
+// puts the real commands back.
+func fakeCommands(t *testing.T) (func() []fakeRun, func()) {
+	dir, err := ioutil.TempDir("", "vinstaller")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var args [][]string
+	command = func(ctx context.Context, name string, arg ...string) *exec.Cmd {
+		in := filepath.Join(dir, strconv.Itoa(len(args)))
+		args = append(args, append([]string{name}, arg...))
+		return exec.CommandContext(ctx, "sh", "-c", `cat >"$0"; if [ "$1" = blkid ]; then echo 0123-4567; fi`, in, name)
+	}
+	ran := func() []fakeRun {
+		out := []fakeRun{}
+		for n, a := range args {
+			b, _ := ioutil.ReadFile(filepath.Join(dir, strconv.Itoa(n)))
+			out = append(out, fakeRun{a, string(b)})
+		}
+		return out
+	}
+	return ran, func() {
+		command = exec.CommandContext
+		os.RemoveAll(dir)
+	}
+}
+
+func layoutInstaller(dryRun bool) *Installer {
+	i := testInstaller("", false)
+	i.DryRun = dryRun
+	i.System = &sysinfo.System{}
+	i.Config = &config.Config{
+		Layout: config.Layout{Disk: "/dev/mmcblk0", Scheme: "luks", Passphrase: "hunter2"},
+		Filesystems: []config.Filesystem{
+			{FS: "/dev/sdb1", MountTo: "/srv", Type: "xfs", Format: true},
+		},
+	}
+	i.collectSecrets()
+	return i
+}
+
+// output drains what the installer has sent to the frontend, up to
+// the channel being closed or empty.
+func output(i *Installer) string {
+	lines := []string{}
+	for {
+		select {
+		case o, ok := <-i.Output:
+			if !ok {
+				return strings.Join(lines, "\n")
+			}
+			lines = append(lines, o.String())
+		default:
+			return strings.Join(lines, "\n")
+		}
+	}
+}
+
+func TestPrepareDisks(t *testing.T) {
+	ran, restore := fakeCommands(t)
+	defer restore()
+
+	i := layoutInstaller(false)
+	if err := i.prepareDisks(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	runs := ran()
+	if len(runs) == 0 || strings.Join(runs[0].args, " ") != "wipefs --all /dev/mmcblk0" {
+		t.Fatalf("the first command run was %v, want wipefs", runs)
+	}
+
+	var luksFormat, mkfsXFS bool
+	for _, r := range runs {
+		for _, a := range r.args {
+			if strings.Contains(a, "hunter2") {
+				t.Errorf("%q has the passphrase as an argument", r.args)
+			}
+		}
+		switch strings.Join(r.args, " ") {
+		case "cryptsetup luksFormat --batch-mode --key-file - /dev/mmcblk0p3":
+			luksFormat = r.input == "hunter2"
+		case "mkfs.xfs -f /dev/sdb1":
+			mkfsXFS = true
+		}
+	}
+	if !luksFormat {
+		t.Errorf("luksFormat wasn't run on /dev/mmcblk0p3 with the passphrase on stdin: %v", runs)
+	}
+	if !mkfsXFS {
+		t.Errorf("the configured filesystem wasn't formatted: %v", runs)
+	}
+	if strings.Contains(output(i), "hunter2") {
+		t.Errorf("the passphrase was shown")
+	}
+
+	if i.plan.CryptUUID != "0123-4567" {
+		t.Errorf("CryptUUID = %q", i.plan.CryptUUID)
+	}
+	if fs := i.Config.Filesystems[0]; fs.FS != "UUID=0123-4567" || fs.Format {
+		t.Errorf("the first filesystem is %+v, want the layout's by UUID", fs)
+	}
+	if len(i.cleanups) != 1 {
+		t.Errorf("%d cleanups registered, want one to close the encrypted partition", len(i.cleanups))
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ran, restore := fakeCommands(t)
+	defer restore()
+
+	i := layoutInstaller(true)
+	go i.Install(context.Background(), "/nonexistent")
+	if !<-i.Done {
+		t.Fatal("the dry run didn't finish")
+	}
+	if runs := ran(); len(runs) != 0 {
+		t.Errorf("the dry run ran %v", runs)
+	}
+	out := output(i)
+	for _, want := range []string{
+		"$ wipefs --all /dev/mmcblk0",
+		"$ cryptsetup luksFormat --batch-mode --key-file - /dev/mmcblk0p3",
+		"$ mkfs.xfs -f /dev/sdb1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("the dry run didn't show %q:\n%s", want, out)
+		}
+	}
+}
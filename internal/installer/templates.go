@@ -0,0 +1,171 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+	"github.com/the-maldridge/vInstaller/internal/keyboard"
+	"github.com/the-maldridge/vInstaller/internal/layout"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// TemplateData is what every template is rendered with, built in
+// or otherwise.
+type TemplateData struct {
+	Config *config.Config
+	Meta   *config.Meta
+	System *sysinfo.System
+
+	// Layout is how the disk was laid out, nil if it wasn't.
+	Layout *layout.Plan
+}
+
+// templateFuncs are the helpers available to templates in addition
+// to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"join":   strings.Join,
+	"split":  strings.Split,
+	"fields": strings.Fields,
+	"lower":  strings.ToLower,
+	"upper":  strings.ToUpper,
+	"trim":   strings.TrimSpace,
+	"quote":  shellQuote,
+	"default": func(def, v interface{}) interface{} {
+		if v == nil || v == "" || v == 0 {
+			return def
+		}
+		return v
+	},
+	"shortname": func(name string) string {
+		return strings.Split(name, ".")[0]
+	},
+	"xkblayout": func(keymap string) string {
+		x, _ := keyboard.ToXKB(keymap)
+		return x.Layout
+	},
+	"xkbvariant": func(keymap string) string {
+		x, _ := keyboard.ToXKB(keymap)
+		return x.Variant
+	},
+	"ipv6": func(addr string) bool {
+		return strings.Contains(addr, ":")
+	},
+}
+
+// shellQuote quotes a string for a file that is sourced by the
+// shell, such as rc.conf.
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'\''`, -1) + "'"
+}
+
+// fetchTemplate loads the named template.  A template of the same
+// name in the override directory takes precedence over the built in
+// one, and templates that are not built in can only come from there.
+// Absolute names are loaded as is.  Without an override directory
+// nothing but the built in templates is looked at, so whatever
+// happens to be in the working directory isn't picked up.
+func (i *Installer) fetchTemplate(name string) (*template.Template, error) {
+	var templateString []byte
+	err := os.ErrNotExist
+	switch {
+	case filepath.IsAbs(name):
+		templateString, err = ioutil.ReadFile(name)
+	case i.Meta.TemplateDir != "":
+		templateString, err = ioutil.ReadFile(filepath.Join(i.Meta.TemplateDir, name))
+	}
+	if os.IsNotExist(err) && !filepath.IsAbs(name) {
+		templateString, err = Asset("templates/" + name)
+	}
+	if err != nil {
+		log.Printf("Could not load template %s: %v", name, err)
+		return nil, err
+	}
+
+	return template.New(filepath.Base(name)).Funcs(templateFuncs).Parse(string(templateString))
+}
+
+func (i *Installer) templateData() TemplateData {
+	return TemplateData{
+		Config: i.Config,
+		Meta:   i.Meta,
+		System: i.System,
+		Layout: i.plan,
+	}
+}
+
+// writeTemplate renders the named template into a file in the
+// target, which path is relative to, after backing it up.
+func (i *Installer) writeTemplate(name, path string, mode os.FileMode) error {
+	if err := i.backupFile(path); err != nil {
+		return err
+	}
+	return i.renderTemplate(name, path, mode)
+}
+
+// renderTemplate renders the named template into a file in the
+// target without backing it up first.
+func (i *Installer) renderTemplate(name, path string, mode os.FileMode) error {
+	t, err := i.fetchTemplate(name)
+	if err != nil {
+		i.Errors <- err
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(i.target, path), os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		i.Errors <- err
+		return err
+	}
+	if err := t.Execute(f, i.templateData()); err != nil {
+		f.Close()
+		i.Errors <- err
+		return err
+	}
+	if err := f.Close(); err != nil {
+		i.Errors <- err
+		return err
+	}
+	// The mode given to OpenFile is subject to the umask and
+	// ignored for existing files.
+	if err := os.Chmod(filepath.Join(i.target, path), mode); err != nil {
+		i.Errors <- err
+		return err
+	}
+	return nil
+}
+
+// writeFiles renders the additional files from the configuration.
+func (i *Installer) writeFiles(ctx context.Context) error {
+	if len(i.Config.Files) == 0 {
+		return nil
+	}
+	i.Output <- i18n.M("installer.files")
+	for _, f := range i.Config.Files {
+		path := strings.TrimPrefix(filepath.Clean("/"+f.Path), "/")
+		if path == "" {
+			err := fmt.Errorf("file from template %s has no path", f.Template)
+			i.Errors <- err
+			return err
+		}
+		mode := f.Mode
+		if mode == 0 {
+			mode = 0644
+		}
+		i.Output <- i18n.Text("  /" + path)
+		if err := os.MkdirAll(filepath.Join(i.target, filepath.Dir(path)), 0755); err != nil {
+			i.Errors <- err
+			return err
+		}
+		if err := i.writeTemplate(f.Template, path, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
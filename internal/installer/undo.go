@@ -0,0 +1,207 @@
+package installer
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// backupDir is where files are saved before the installer replaces
+// them, relative to the root of the target.
+const backupDir = "var/lib/vInstaller/backup"
+
+// An undoAction reverts a single change a step made to the target.
+type undoAction struct {
+	backup
+	desc string
+	fn   func() error
+}
+
+// A backup is recorded in the journal for every path backupFile
+// saves, so that the action putting it back can be registered again
+// when resuming.
+type backup struct {
+	Step string `json:"step"`
+	Path string `json:"path"`
+
+	// Kind is what was at the path: nothing, a symlink to Link,
+	// or a file that was copied to backupDir.
+	Kind string `json:"kind"`
+	Link string `json:"link,omitempty"`
+}
+
+const (
+	backupMissing = "none"
+	backupLink    = "symlink"
+	backupCopy    = "file"
+)
+
+// backupFile must be called before a step changes a file in the
+// target.  It saves whatever is at path, which is relative to the
+// target, records it in the journal and registers an action to put
+// it back.  If nothing is there the action removes whatever the step
+// creates instead.  Only the first call for a path has any effect,
+// and when resuming that includes the calls made before the
+// installation was interrupted, so the file is always restored to
+// the state it was in before the installer touched it.
+func (i *Installer) backupFile(path string) error {
+	if i.backedUp == nil {
+		i.backedUp = make(map[string]bool)
+	}
+	if i.backedUp[path] {
+		return nil
+	}
+
+	abs := filepath.Join(i.target, path)
+	b := backup{Step: i.current, Path: path}
+	info, err := os.Lstat(abs)
+	switch {
+	case os.IsNotExist(err):
+		b.Kind = backupMissing
+	case err != nil:
+		i.Errors <- err
+		return err
+	case info.IsDir():
+		// Directories that already exist are left as they
+		// are, only ones the installer creates are removed.
+		i.backedUp[path] = true
+		return nil
+	case info.Mode()&os.ModeSymlink != 0:
+		b.Kind = backupLink
+		b.Link, err = os.Readlink(abs)
+		if err != nil {
+			i.Errors <- err
+			return err
+		}
+	default:
+		// A backup left by an earlier attempt that isn't in
+		// the journal was made before that attempt changed
+		// the file, so copying over it loses nothing.
+		b.Kind = backupCopy
+		if err := copyFile(abs, filepath.Join(i.target, backupDir, path), info); err != nil {
+			i.Errors <- err
+			return err
+		}
+	}
+
+	i.journal.Backups = append(i.journal.Backups, b)
+	if err := i.saveJournal(); err != nil {
+		i.Errors <- err
+		return err
+	}
+	i.undo = append(i.undo, i.undoFor(b))
+	i.backedUp[path] = true
+	return nil
+}
+
+// undoFor returns the action that puts back what b saved.
+func (i *Installer) undoFor(b backup) undoAction {
+	abs := filepath.Join(i.target, b.Path)
+	switch b.Kind {
+	case backupMissing:
+		return undoAction{b, "Removed /" + b.Path, func() error {
+			err := os.RemoveAll(abs)
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}}
+	case backupLink:
+		return undoAction{b, "Restored /" + b.Path, func() error {
+			if err := os.Remove(abs); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			return os.Symlink(b.Link, abs)
+		}}
+	default:
+		return undoAction{b, "Restored /" + b.Path, func() error {
+			return os.Rename(filepath.Join(i.target, backupDir, b.Path), abs)
+		}}
+	}
+}
+
+// restoreUndo registers the actions for the backups recorded in the
+// journal of an interrupted installation, so that a rollback of the
+// resumed one also reverts what the steps run before did.
+func (i *Installer) restoreUndo() {
+	i.backedUp = make(map[string]bool)
+	for _, b := range i.journal.Backups {
+		i.undo = append(i.undo, i.undoFor(b))
+		i.backedUp[b.Path] = true
+	}
+}
+
+// copyFile copies a regular file, keeping its mode and ownership.
+func copyFile(src, dst string, info os.FileInfo) error {
+	if err := os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+		return err
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		if err := os.Chown(dst, int(st.Uid), int(st.Gid)); err != nil {
+			return err
+		}
+	}
+	return os.Chmod(dst, info.Mode().Perm())
+}
+
+// rollback reverts every change registered by the steps run so far,
+// newest first, and returns a description of each change that was
+// reverted.  The journal is cut back to before the earliest step
+// that had a change reverted so that a resumed installation redoes
+// it and everything after it.
+func (i *Installer) rollback() []string {
+	log.Println("Rolling back configuration")
+	restored := []string{}
+	undone := make(map[string]bool)
+	kept := []backup{}
+	for n := len(i.undo) - 1; n >= 0; n-- {
+		u := i.undo[n]
+		undone[u.Step] = true
+		if err := u.fn(); err != nil {
+			log.Printf("Could not undo %s: %v", u.desc, err)
+			i.Errors <- err
+			kept = append([]backup{u.backup}, kept...)
+			continue
+		}
+		restored = append(restored, u.desc)
+	}
+
+	if len(i.undo) > 0 {
+		for n, s := range i.completed {
+			if undone[s] {
+				i.completed = i.completed[:n]
+				break
+			}
+		}
+		if len(i.journal.Entries) > len(i.completed) {
+			i.journal.Entries = i.journal.Entries[:len(i.completed)]
+		}
+		// Backups that could not be put back are still
+		// there for the next attempt to restore.
+		i.journal.Backups = kept
+		if err := i.saveJournal(); err != nil {
+			log.Printf("Could not save journal: %v", err)
+		}
+	}
+	i.undo = nil
+	i.backedUp = nil
+	return restored
+}
@@ -0,0 +1,111 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/the-maldridge/vInstaller/internal/i18n"
+)
+
+// testInstaller returns an installer for target whose channels are
+// big enough that nobody needs to read them.
+func testInstaller(target string, resume bool) *Installer {
+	return &Installer{
+		Output:   make(chan i18n.Message, 100),
+		Errors:   make(chan error, 100),
+		Done:     make(chan bool, 1),
+		Resume:   resume,
+		Rollback: true,
+		target:   target,
+	}
+}
+
+func TestRollbackAfterResume(t *testing.T) {
+	target, err := ioutil.TempDir("", "vinstaller")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(target)
+	passwd := filepath.Join(target, "etc/passwd")
+	if err := os.MkdirAll(filepath.Dir(passwd), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(passwd, []byte("base-system\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	errRoot := errors.New("root failed")
+	ran := []string{}
+	steps := func(i *Installer) []step {
+		// Both steps change the same file, as users and root
+		// both change etc/passwd.
+		edit := func(name string, fail bool) func(context.Context) error {
+			return func(context.Context) error {
+				ran = append(ran, name)
+				if err := i.backupFile("etc/passwd"); err != nil {
+					return err
+				}
+				if err := ioutil.WriteFile(passwd, []byte(name+"\n"), 0644); err != nil {
+					return err
+				}
+				if fail {
+					return errRoot
+				}
+				return nil
+			}
+		}
+		return []step{
+			{"base-system", func(context.Context) error { return nil }, nil},
+			{"users", edit("users", false), "users"},
+			{"root", edit("root", true), "root"},
+		}
+	}
+
+	// The first attempt fails without rolling back.
+	i := testInstaller(target, false)
+	if err := i.run(context.Background(), steps(i)); err != errRoot {
+		t.Fatalf("first attempt returned %v, want %v", err, errRoot)
+	}
+
+	// The resumed attempt skips users, fails at root again and
+	// rolls back, which has to undo what users did before.
+	ran = nil
+	i = testInstaller(target, true)
+	if err := i.run(context.Background(), steps(i)); err != errRoot {
+		t.Fatalf("resumed attempt returned %v, want %v", err, errRoot)
+	}
+	if len(ran) != 1 || ran[0] != "root" {
+		t.Errorf("resuming ran %v, want [root]", ran)
+	}
+	i.rollback()
+
+	b, err := ioutil.ReadFile(passwd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "base-system\n" {
+		t.Errorf("rollback left etc/passwd as %q", b)
+	}
+	j, err := i.loadJournal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(j.Entries) != 1 || j.Entries[0].Step != "base-system" {
+		t.Errorf("rollback left the journal with %v, want only base-system", j.Entries)
+	}
+	if len(j.Backups) != 0 {
+		t.Errorf("rollback left backups %v in the journal", j.Backups)
+	}
+
+	// Resuming again runs users as well.
+	ran = nil
+	i = testInstaller(target, true)
+	i.run(context.Background(), steps(i))
+	if len(ran) != 2 || ran[0] != "users" {
+		t.Errorf("resuming after the rollback ran %v, want [users root]", ran)
+	}
+}
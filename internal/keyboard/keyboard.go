@@ -0,0 +1,178 @@
+// Package keyboard catalogues the keyboard layouts the installer can
+// configure.  Layouts are chosen by their console keymap, which is
+// then mapped to the XKB layout and variant X11 needs.
+package keyboard
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/the-maldridge/vInstaller/internal/console"
+)
+
+// ErrUnknownKeymap is returned for a keymap that kbd does not have.
+var ErrUnknownKeymap = errors.New("keymap is not known")
+
+// An XKB layout and variant pair.
+type XKB struct {
+	Layout  string
+	Variant string
+}
+
+// xkbMap maps console keymaps to XKB where the names don't line up.
+// It follows the kbd-model-map used by systemd-localed.
+var xkbMap = map[string]XKB{
+	"us":                       {"us", ""},
+	"us-acentos":               {"us", "intl"},
+	"uk":                       {"gb", ""},
+	"dvorak":                   {"us", "dvorak"},
+	"dvorak-programmer":        {"us", "dvp"},
+	"colemak":                  {"us", "colemak"},
+	"de":                       {"de", ""},
+	"de-latin1":                {"de", ""},
+	"de-latin1-nodeadkeys":     {"de", "nodeadkeys"},
+	"de_CH-latin1":             {"ch", "de"},
+	"fr":                       {"fr", ""},
+	"fr-latin1":                {"fr", ""},
+	"fr-latin9":                {"fr", "latin9"},
+	"fr-pc":                    {"fr", ""},
+	"fr-bepo":                  {"fr", "bepo"},
+	"fr_CH":                    {"ch", "fr"},
+	"fr_CH-latin1":             {"ch", "fr"},
+	"cf":                       {"ca", "fr"},
+	"ca":                       {"ca", ""},
+	"sg":                       {"ch", "de_nodeadkeys"},
+	"sg-latin1":                {"ch", "de_nodeadkeys"},
+	"es":                       {"es", ""},
+	"la-latin1":                {"latam", ""},
+	"it":                       {"it", ""},
+	"it2":                      {"it", ""},
+	"pt-latin1":                {"pt", ""},
+	"pt-latin9":                {"pt", ""},
+	"br-abnt2":                 {"br", ""},
+	"br-latin1-abnt2":          {"br", ""},
+	"be-latin1":                {"be", ""},
+	"nl":                       {"nl", ""},
+	"nl2":                      {"nl", ""},
+	"dk":                       {"dk", ""},
+	"dk-latin1":                {"dk", ""},
+	"no":                       {"no", ""},
+	"no-latin1":                {"no", ""},
+	"se-lat6":                  {"se", ""},
+	"se-latin1":                {"se", ""},
+	"sv-latin1":                {"se", ""},
+	"fi":                       {"fi", ""},
+	"fi-latin1":                {"fi", ""},
+	"is-latin1":                {"is", ""},
+	"pl":                       {"pl", ""},
+	"pl2":                      {"pl", ""},
+	"cz":                       {"cz", ""},
+	"cz-qwerty":                {"cz", "qwerty"},
+	"cz-lat2":                  {"cz", "qwerty"},
+	"sk-qwerty":                {"sk", "qwerty"},
+	"sk-qwertz":                {"sk", ""},
+	"slovene":                  {"si", ""},
+	"croat":                    {"hr", ""},
+	"hu":                       {"hu", ""},
+	"hu101":                    {"hu", "qwerty"},
+	"ro_std":                   {"ro", "std"},
+	"ro":                       {"ro", ""},
+	"bg_bds-utf8":              {"bg", ""},
+	"ru":                       {"ru", ""},
+	"ru-winkeys":               {"ru", ""},
+	"ua":                       {"ua", ""},
+	"ua-utf":                   {"ua", ""},
+	"by":                       {"by", ""},
+	"gr":                       {"gr", ""},
+	"trq":                      {"tr", ""},
+	"trf":                      {"tr", "f"},
+	"tr_q-latin5":              {"tr", ""},
+	"tr_f-latin5":              {"tr", "f"},
+	"il":                       {"il", ""},
+	"et":                       {"ee", ""},
+	"lt":                       {"lt", ""},
+	"lv":                       {"lv", ""},
+	"mk":                       {"mk", ""},
+	"sr-cy":                    {"rs", ""},
+	"jp106":                    {"jp", ""},
+	"kr":                       {"kr", ""},
+	"tj_alt-UTF8":              {"tj", ""},
+	"ie":                       {"ie", ""},
+	"emacs":                    {"us", ""},
+	"emacs2":                   {"us", ""},
+	"dvorak-l":                 {"us", "dvorak-l"},
+	"dvorak-r":                 {"us", "dvorak-r"},
+	"dvorak-fr":                {"fr", "dvorak"},
+	"dvorak-es":                {"es", "dvorak"},
+	"dvorak-ukp":               {"gb", "dvorak"},
+	"mac-us":                   {"us", "mac"},
+	"mac-uk":                   {"gb", "mac"},
+	"mac-de-latin1":            {"de", "mac"},
+	"mac-de-latin1-nodeadkeys": {"de", "mac_nodeadkeys"},
+	"mac-fr":                   {"fr", "mac"},
+	"mac-es":                   {"es", "mac"},
+	"mac-it":                   {"it", "mac"},
+	"mac-pt-latin1":            {"pt", "mac"},
+	"mac-se":                   {"se", "mac"},
+	"mac-dk-latin1":            {"dk", "mac"},
+	"mac-fi-latin1":            {"fi", "mac"},
+	"mac-be":                   {"be", "mac"},
+}
+
+// Keymaps returns the console keymaps available on the system at
+// root.
+func Keymaps(root string) ([]string, error) {
+	return console.Keymaps(root)
+}
+
+// Validate checks that the system at root has the keymap.  As nothing
+// can be checked on a system without kbd, anything is allowed there.
+// An empty keymap leaves the default in place.
+func Validate(root, keymap string) error {
+	if keymap == "" || !console.Available(root) {
+		return nil
+	}
+	keymaps, err := Keymaps(root)
+	if err != nil {
+		return err
+	}
+	if !console.Contains(keymaps, keymap) {
+		return ErrUnknownKeymap
+	}
+	return nil
+}
+
+// ToXKB returns the XKB layout and variant for a console keymap.
+// Keymaps that aren't in the table are assumed to be named after
+// their layout, less any suffix, which is right for most of the
+// remainder.  ok is false if the answer is such a guess.
+func ToXKB(keymap string) (x XKB, ok bool) {
+	if x, ok := xkbMap[keymap]; ok {
+		return x, true
+	}
+	layout := strings.ToLower(keymap)
+	if i := strings.IndexAny(layout, "-_"); i > 0 {
+		layout = layout[:i]
+	}
+	layout = strings.TrimRight(layout, "0123456789")
+	if layout == "" {
+		layout = "us"
+	}
+	return XKB{Layout: layout}, false
+}
+
+// graphical are the package groups that bring in an X server.
+var graphical = []string{"xorg", "xorg-minimal", "xorg-server"}
+
+// Graphical returns true if pkgs include an X server, and so an X11
+// keyboard configuration is needed.
+func Graphical(pkgs []string) bool {
+	for _, p := range pkgs {
+		for _, g := range graphical {
+			if p == g {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,296 @@
+// Package layout works out how a whole disk is partitioned and
+// formatted for one of the guided layouts, and which filesystems the
+// installed system is left with.  Nothing here touches a disk, the
+// installer runs the commands a Plan returns.
+package layout
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+	"github.com/the-maldridge/vInstaller/internal/sysinfo"
+)
+
+// ErrUnknownScheme is returned for a scheme that isn't one of
+// Schemes.
+var ErrUnknownScheme = errors.New("unknown layout scheme")
+
+// A Scheme is a guided layout that can be chosen.
+type Scheme struct {
+	Name        string
+	Description string
+}
+
+// Schemes are the guided layouts, the first being the default.
+var Schemes = []Scheme{
+	{"ext4", "a single ext4 filesystem"},
+	{"btrfs", "btrfs with subvolumes for / and /home, compressed"},
+	{"luks", "ext4 on LVM in an encrypted partition, with an unencrypted /boot"},
+}
+
+const (
+	volumeGroup = "void"
+	cryptName   = "cryptroot"
+
+	// biosBoot is the GPT type of the partition GRUB embeds
+	// itself in when booting from BIOS.
+	biosBoot = "21686148-6449-6E6F-744E-656564454649"
+)
+
+// A Partition is one partition to create.  A Size of zero takes up
+// the rest of the disk.
+type Partition struct {
+	Number int
+	Name   string
+	Type   string
+	SizeMB int
+}
+
+// A Command is a command to run, with Input fed to its stdin.
+type Command struct {
+	Args  []string
+	Input string
+}
+
+// Plan is everything that will be done to a disk for a layout.
+type Plan struct {
+	Disk   string
+	Scheme string
+	EFI    bool
+
+	Partitions []Partition
+
+	// Filesystems are what is mounted in the target, their FS
+	// being the device that is formatted.  Subvolumes of one
+	// btrfs filesystem share the device.
+	Filesystems []config.Filesystem
+
+	// Packages are needed in the target to boot from the layout.
+	Packages []string
+
+	// CryptDevice is the partition that is encrypted, if any,
+	// which is opened as CryptName and holds VolumeGroup.
+	// CryptUUID is filled in by the installer once it has been
+	// formatted.
+	CryptDevice string
+	CryptName   string
+	CryptUUID   string
+	VolumeGroup string
+
+	passphrase string
+}
+
+// New plans a layout, for a machine booted with UEFI if efi is set.
+func New(l config.Layout, efi bool) (*Plan, error) {
+	if err := config.ValidateLayout(l); err != nil {
+		return nil, err
+	}
+	p := &Plan{Disk: l.Disk, Scheme: l.Scheme, EFI: efi, passphrase: l.Passphrase}
+
+	if efi {
+		p.addPartition("EFI", "U", 512)
+		p.Filesystems = append(p.Filesystems, config.Filesystem{
+			FS:      p.lastPartition(),
+			MountTo: "/boot/efi",
+			Type:    "vfat",
+			Options: "defaults",
+			Pass:    2,
+		})
+	} else {
+		p.addPartition("BIOS", biosBoot, 1)
+	}
+
+	switch l.Scheme {
+	case "ext4":
+		p.addPartition("root", "L", 0)
+		p.Filesystems = append(p.Filesystems, rootFS(p.lastPartition(), "ext4", "defaults"))
+	case "btrfs":
+		p.addPartition("root", "L", 0)
+		dev := p.lastPartition()
+		p.Filesystems = append(p.Filesystems,
+			rootFS(dev, "btrfs", "subvol=@,compress=zstd"),
+			config.Filesystem{FS: dev, MountTo: "/home", Type: "btrfs", Options: "subvol=@home,compress=zstd"},
+			config.Filesystem{FS: dev, MountTo: "/.snapshots", Type: "btrfs", Options: "subvol=@snapshots,compress=zstd"},
+		)
+		p.Packages = []string{"btrfs-progs"}
+	case "luks":
+		// GRUB can't read every kind of LUKS2 header, so the
+		// kernel and initramfs are kept outside.
+		p.addPartition("boot", "L", 1024)
+		boot := p.lastPartition()
+		p.addPartition("crypt", "L", 0)
+		p.CryptDevice = p.lastPartition()
+		p.CryptName = cryptName
+		p.VolumeGroup = volumeGroup
+		p.Filesystems = append(p.Filesystems,
+			rootFS(p.RootVolume(), "ext4", "defaults"),
+			config.Filesystem{FS: boot, MountTo: "/boot", Type: "ext4", Options: "defaults", Pass: 2},
+		)
+		p.Packages = []string{"cryptsetup", "lvm2"}
+	default:
+		return nil, ErrUnknownScheme
+	}
+	for n := range p.Filesystems {
+		p.Filesystems[n].Format = true
+	}
+	return p, nil
+}
+
+// RootVolume is the logical volume the root filesystem of the luks
+// scheme is on.
+func (p *Plan) RootVolume() string {
+	return "/dev/mapper/" + p.VolumeGroup + "-root"
+}
+
+// Mapped is the device the encrypted partition is opened as.
+func (p *Plan) Mapped() string {
+	return "/dev/mapper/" + p.CryptName
+}
+
+func rootFS(dev, fstype, options string) config.Filesystem {
+	return config.Filesystem{FS: dev, MountTo: "/", Type: fstype, Options: options, Pass: 1}
+}
+
+func (p *Plan) addPartition(name, typ string, sizeMB int) {
+	p.Partitions = append(p.Partitions, Partition{
+		Number: len(p.Partitions) + 1,
+		Name:   name,
+		Type:   typ,
+		SizeMB: sizeMB,
+	})
+}
+
+func (p *Plan) lastPartition() string {
+	return PartitionPath(p.Disk, len(p.Partitions))
+}
+
+// PartitionPath returns the device of partition n of disk.  Disks
+// whose names end in a digit, such as nvme0n1 and mmcblk0, put a p
+// before the number.
+func PartitionPath(disk string, n int) string {
+	name := filepath.Base(disk)
+	if name != "" && unicode.IsDigit(rune(name[len(name)-1])) {
+		return fmt.Sprintf("%sp%d", disk, n)
+	}
+	return fmt.Sprintf("%s%d", disk, n)
+}
+
+// Script returns the partition table as input for sfdisk.
+func (p *Plan) Script() string {
+	out := []string{"label: gpt"}
+	for _, part := range p.Partitions {
+		line := fmt.Sprintf("type=%s, name=%s", part.Type, part.Name)
+		if part.SizeMB > 0 {
+			line = fmt.Sprintf("size=%dMiB, ", part.SizeMB) + line
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n") + "\n"
+}
+
+// Commands returns what has to be run to lay the disk out.  btrfs
+// subvolumes are created with the filesystem mounted on workdir.
+func (p *Plan) Commands(workdir string) []Command {
+	cmds := []Command{
+		{Args: []string{"wipefs", "--all", p.Disk}},
+		{Args: []string{"sfdisk", "--wipe", "always", p.Disk}, Input: p.Script()},
+		{Args: []string{"udevadm", "settle"}},
+	}
+
+	if p.CryptDevice != "" {
+		cmds = append(cmds,
+			Command{Args: []string{"cryptsetup", "luksFormat", "--batch-mode", "--key-file", "-", p.CryptDevice}, Input: p.passphrase},
+			p.Open(),
+			Command{Args: []string{"pvcreate", p.Mapped()}},
+			Command{Args: []string{"vgcreate", p.VolumeGroup, p.Mapped()}},
+			Command{Args: []string{"lvcreate", "--yes", "-l", "100%FREE", "-n", "root", p.VolumeGroup}},
+			Command{Args: []string{"udevadm", "settle"}},
+		)
+	}
+
+	formatted := make(map[string]bool)
+	for _, f := range p.Filesystems {
+		if formatted[f.FS] {
+			continue
+		}
+		formatted[f.FS] = true
+		cmds = append(cmds, Command{Args: Mkfs(f)})
+	}
+
+	if p.Scheme == "btrfs" {
+		dev := p.Filesystems[len(p.Filesystems)-1].FS
+		cmds = append(cmds, Command{Args: []string{"mount", "-t", "btrfs", dev, workdir}})
+		for _, sub := range []string{"@", "@home", "@snapshots"} {
+			cmds = append(cmds, Command{Args: []string{"btrfs", "subvolume", "create", filepath.Join(workdir, sub)}})
+		}
+		cmds = append(cmds, Command{Args: []string{"umount", workdir}})
+	}
+	return cmds
+}
+
+// Open returns the command that opens the encrypted partition.
+func (p *Plan) Open() Command {
+	return Command{Args: []string{"cryptsetup", "open", "--key-file", "-", p.CryptDevice, p.CryptName}, Input: p.passphrase}
+}
+
+// Mkfs returns the command that creates a filesystem, overwriting
+// whatever is there.
+func Mkfs(f config.Filesystem) []string {
+	switch f.Type {
+	case "vfat":
+		return []string{"mkfs.vfat", "-F", "32", f.FS}
+	case "swap":
+		return []string{"mkswap", f.FS}
+	case "btrfs", "xfs", "f2fs":
+		return []string{"mkfs." + f.Type, "-f", f.FS}
+	}
+	return []string{"mkfs." + f.Type, "-F", f.FS}
+}
+
+// Describe says what the disk will end up with, a line for each
+// partition.
+func (p *Plan) Describe() []string {
+	out := []string{}
+	for _, part := range p.Partitions {
+		dev := PartitionPath(p.Disk, part.Number)
+		size := "the rest of the disk"
+		if part.SizeMB > 0 {
+			size = fmt.Sprintf("%d MiB", part.SizeMB)
+		}
+		var what []string
+		switch {
+		case dev == p.CryptDevice:
+			what = append(what, "encrypted, LVM volume group "+p.VolumeGroup)
+			for _, f := range p.Filesystems {
+				if f.FS == p.RootVolume() {
+					what = append(what, f.Type+" on "+f.MountTo)
+				}
+			}
+		case part.Type == biosBoot:
+			what = append(what, "BIOS boot partition for GRUB")
+		}
+		for _, f := range p.Filesystems {
+			if f.FS == dev {
+				what = append(what, f.Type+" on "+f.MountTo)
+			}
+		}
+		out = append(out, fmt.Sprintf("%s %s: %s", dev, size, strings.Join(what, ", ")))
+	}
+	return out
+}
+
+// Erases lists what is on a disk that will be lost.
+func Erases(d sysinfo.Disk) []string {
+	out := []string{}
+	if len(d.Partitions) == 0 {
+		return append(out, fmt.Sprintf("everything on %s", d))
+	}
+	for _, p := range d.Partitions {
+		out = append(out, p.String())
+	}
+	return out
+}
@@ -0,0 +1,79 @@
+package layout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/the-maldridge/vInstaller/internal/config"
+)
+
+func TestPartitionPath(t *testing.T) {
+	for disk, want := range map[string]string{
+		"/dev/sda":       "/dev/sda2",
+		"/dev/vdb":       "/dev/vdb2",
+		"/dev/xvda":      "/dev/xvda2",
+		"/dev/nvme0n1":   "/dev/nvme0n1p2",
+		"/dev/mmcblk0":   "/dev/mmcblk0p2",
+		"/dev/loop0":     "/dev/loop0p2",
+		"/dev/md127":     "/dev/md127p2",
+		"/dev/nbd10":     "/dev/nbd10p2",
+		"/dev/mapper/vg": "/dev/mapper/vg2",
+	} {
+		if got := PartitionPath(disk, 2); got != want {
+			t.Errorf("PartitionPath(%q, 2) = %q, want %q", disk, got, want)
+		}
+	}
+}
+
+func TestCommands(t *testing.T) {
+	p, err := New(config.Layout{Disk: "/dev/mmcblk0", Scheme: "luks", Passphrase: "hunter2"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.CryptDevice != "/dev/mmcblk0p3" {
+		t.Errorf("CryptDevice = %q, want /dev/mmcblk0p3", p.CryptDevice)
+	}
+
+	want := [][]string{
+		{"wipefs", "--all", "/dev/mmcblk0"},
+		{"sfdisk", "--wipe", "always", "/dev/mmcblk0"},
+		{"udevadm", "settle"},
+		{"cryptsetup", "luksFormat", "--batch-mode", "--key-file", "-", "/dev/mmcblk0p3"},
+		{"cryptsetup", "open", "--key-file", "-", "/dev/mmcblk0p3", "cryptroot"},
+		{"pvcreate", "/dev/mapper/cryptroot"},
+		{"vgcreate", "void", "/dev/mapper/cryptroot"},
+		{"lvcreate", "--yes", "-l", "100%FREE", "-n", "root", "void"},
+		{"udevadm", "settle"},
+		{"mkfs.vfat", "-F", "32", "/dev/mmcblk0p1"},
+		{"mkfs.ext4", "-F", "/dev/mapper/void-root"},
+		{"mkfs.ext4", "-F", "/dev/mmcblk0p2"},
+	}
+	cmds := p.Commands("/tmp/work")
+	got := [][]string{}
+	for _, c := range cmds {
+		got = append(got, c.Args)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Commands() =\n%q\nwant\n%q", got, want)
+	}
+
+	// The passphrase only ever goes to stdin.
+	for _, c := range cmds {
+		for _, a := range c.Args {
+			if a == "hunter2" {
+				t.Errorf("%q has the passphrase as an argument", c.Args)
+			}
+		}
+	}
+	if cmds[3].Input != "hunter2" || cmds[4].Input != "hunter2" {
+		t.Errorf("cryptsetup isn't given the passphrase on stdin")
+	}
+
+	script := "label: gpt\n" +
+		"size=512MiB, type=U, name=EFI\n" +
+		"size=1024MiB, type=L, name=boot\n" +
+		"type=L, name=crypt\n"
+	if cmds[1].Input != script {
+		t.Errorf("sfdisk script =\n%s\nwant\n%s", cmds[1].Input, script)
+	}
+}
@@ -0,0 +1,58 @@
+// Package locale lists the glibc locales a system can generate, so
+// that the locale can be picked from a list rather than typed in.
+package locale
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Files that list the supported locales, relative to the root of a
+// system.  Void keeps the list in libc-locales, where the locales to
+// generate are uncommented, glibc's own copy is the fallback.
+var Files = []string{"etc/default/libc-locales", "usr/share/i18n/SUPPORTED"}
+
+// Available returns true if the system at root has a list of
+// locales.
+func Available(root string) bool {
+	for _, f := range Files {
+		if _, err := os.Stat(filepath.Join(root, f)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the names of the locales the system at root supports,
+// such as en_US.UTF-8, sorted.
+func List(root string) ([]string, error) {
+	var err error
+	for _, f := range Files {
+		var b []byte
+		b, err = ioutil.ReadFile(filepath.Join(root, f))
+		if err == nil {
+			return parse(string(b)), nil
+		}
+	}
+	return nil, err
+}
+
+// parse reads lines of the form "en_US.UTF-8 UTF-8", which may be
+// commented out.  Lines that are only comments have no charset.
+func parse(s string) []string {
+	seen := make(map[string]bool)
+	locales := []string{}
+	for _, l := range strings.Split(s, "\n") {
+		fields := strings.Fields(strings.TrimLeft(l, "# \t"))
+		if len(fields) != 2 || seen[fields[0]] {
+			continue
+		}
+		seen[fields[0]] = true
+		locales = append(locales, fields[0])
+	}
+	sort.Strings(locales)
+	return locales
+}
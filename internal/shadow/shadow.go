@@ -0,0 +1,163 @@
+// Package shadow produces password hashes in the crypt(3) formats
+// understood by the target's shadow suite, so that passwords never
+// have to be handed to a command in the clear.
+package shadow
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"strconv"
+	"strings"
+)
+
+// itoa64 is the alphabet crypt(3) encodes hashes and salts with.
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const (
+	sha512Prefix     = "$6$"
+	sha512SaltLength = 16
+	sha512Rounds     = 5000
+)
+
+// hashPrefixes are the crypt(3) methods that may be found in a
+// shadow file.
+var hashPrefixes = []string{"$1$", "$2a$", "$2b$", "$2y$", "$5$", "$6$", "$7$", "$gy$", "$y$"}
+
+// IsHash returns true if s looks like the output of crypt(3) rather
+// than a password.
+func IsHash(s string) bool {
+	for _, p := range hashPrefixes {
+		if strings.HasPrefix(s, p) && strings.Count(s, "$") >= 3 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLocked returns true if s is a shadow password field that can't
+// be logged in with.
+func IsLocked(s string) bool {
+	return strings.HasPrefix(s, "!") || strings.HasPrefix(s, "*")
+}
+
+// SHA512 hashes the password with SHA-512 crypt and a random salt.
+func SHA512(password string) (string, error) {
+	salt, err := randomSalt(sha512SaltLength)
+	if err != nil {
+		return "", err
+	}
+	return sha512Crypt([]byte(password), salt, sha512Rounds), nil
+}
+
+func randomSalt(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	for i := range b {
+		b[i] = itoa64[int(b[i])%len(itoa64)]
+	}
+	return b, nil
+}
+
+// sha512Crypt implements the SHA-512 based crypt(3) method as
+// specified by Ulrich Drepper in "Unix crypt using SHA-256 and
+// SHA-512".
+func sha512Crypt(key, salt []byte, rounds int) string {
+	if len(salt) > sha512SaltLength {
+		salt = salt[:sha512SaltLength]
+	}
+
+	b := sha512.New()
+	b.Write(key)
+	b.Write(salt)
+	b.Write(key)
+	sumB := b.Sum(nil)
+
+	a := sha512.New()
+	a.Write(key)
+	a.Write(salt)
+	a.Write(repeat(sumB, len(key)))
+	for n := len(key); n > 0; n >>= 1 {
+		if n&1 != 0 {
+			a.Write(sumB)
+		} else {
+			a.Write(key)
+		}
+	}
+	sumA := a.Sum(nil)
+
+	dp := sha512.New()
+	for range key {
+		dp.Write(key)
+	}
+	p := repeat(dp.Sum(nil), len(key))
+
+	ds := sha512.New()
+	for n := 0; n < 16+int(sumA[0]); n++ {
+		ds.Write(salt)
+	}
+	s := repeat(ds.Sum(nil), len(salt))
+
+	for n := 0; n < rounds; n++ {
+		c := sha512.New()
+		if n&1 != 0 {
+			c.Write(p)
+		} else {
+			c.Write(sumA)
+		}
+		if n%3 != 0 {
+			c.Write(s)
+		}
+		if n%7 != 0 {
+			c.Write(p)
+		}
+		if n&1 != 0 {
+			c.Write(sumA)
+		} else {
+			c.Write(p)
+		}
+		sumA = c.Sum(nil)
+	}
+
+	out := []byte(sha512Prefix)
+	if rounds != sha512Rounds {
+		out = append(out, []byte("rounds="+strconv.Itoa(rounds)+"$")...)
+	}
+	out = append(out, salt...)
+	out = append(out, '$')
+	for _, g := range sha512Order {
+		out = encode24(out, sumA[g[0]], sumA[g[1]], sumA[g[2]], 4)
+	}
+	out = encode24(out, 0, 0, sumA[63], 2)
+	return string(out)
+}
+
+// sha512Order is the order the bytes of the final digest are encoded
+// in, three at a time.
+var sha512Order = [][3]int{
+	{0, 21, 42}, {22, 43, 1}, {44, 2, 23}, {3, 24, 45},
+	{25, 46, 4}, {47, 5, 26}, {6, 27, 48}, {28, 49, 7},
+	{50, 8, 29}, {9, 30, 51}, {31, 52, 10}, {53, 11, 32},
+	{12, 33, 54}, {34, 55, 13}, {56, 14, 35}, {15, 36, 57},
+	{37, 58, 16}, {59, 17, 38}, {18, 39, 60}, {40, 61, 19},
+	{62, 20, 41},
+}
+
+func encode24(out []byte, b2, b1, b0 byte, n int) []byte {
+	w := uint(b2)<<16 | uint(b1)<<8 | uint(b0)
+	for ; n > 0; n-- {
+		out = append(out, itoa64[w&0x3f])
+		w >>= 6
+	}
+	return out
+}
+
+// repeat returns the first n bytes of sum repeated end to end.
+func repeat(sum []byte, n int) []byte {
+	out := make([]byte, 0, n)
+	for len(out)+len(sum) <= n {
+		out = append(out, sum...)
+	}
+	return append(out, sum[:n-len(out)]...)
+}
@@ -0,0 +1,56 @@
+package shadow
+
+import (
+	"strings"
+	"testing"
+)
+
+// The test vectors published with "Unix crypt using SHA-256 and
+// SHA-512".  Their rounds are given here rather than parsed from the
+// setting, and the minimum of 1000 rounds is already applied.
+var sha512Vectors = []struct {
+	salt   string
+	rounds int
+	key    string
+	want   string
+}{
+	{"saltstring", 5000, "Hello world!",
+		"$6$saltstring$svn8UoSVapNtMuq1ukKS4tPQd8iKwSMHWjl/O817G3uBnIFNjnQJuesI68u4OTLiBFdcbYEdFCoEOfaS35inz1"},
+	{"saltstringsaltstring", 10000, "Hello world!",
+		"$6$rounds=10000$saltstringsaltst$OW1/O6BYHV6BcXZu8QVeXbDWra3Oeqh0sbHbbMCVNSnCM/UrjmM0Dp8vOuZeHBy/YTBmSK6H9qs/y3RnOaw5v."},
+	{"toolongsaltstring", 5000, "This is just a test",
+		"$6$toolongsaltstrin$lQ8jolhgVRVhY4b5pZKaysCLi0QBxGoNeKQzQ3glMhwllF7oGDZxUhx1yxdYcz/e1JSbq3y6JMxxl8audkUEm0"},
+	{"anotherlongsaltstring", 1400, "a very much longer text to encrypt.  This one even stretches over morethan one line.",
+		"$6$rounds=1400$anotherlongsalts$POfYwTEok97VWcjxIiSOjiykti.o/pQs.wPvMxQ6Fm7I6IoYN3CmLs66x9t0oSwbtEW7o7UmJEiDwGqd8p4ur1"},
+	{"short", 77777, "we have a short salt string but not a short password",
+		"$6$rounds=77777$short$WuQyW2YR.hBNpjjRhpYD/ifIw05xdfeEyQoMxIXbkvr0gge1a1x3yRULJ5CCaUeOxFmtlcGZelFl5CxtgfiAc0"},
+	{"asaltof16chars..", 123456, "a short string",
+		"$6$rounds=123456$asaltof16chars..$BtCwjqMJGx5hrJhZywWvt0RLE8uZ4oPwcelCjmw2kSYu.Ec6ycULevoBK25fs2xXgMNrCzIMVcgEJAstJeonj1"},
+	{"roundstoolow", 1000, "the minimum number is still observed",
+		"$6$rounds=1000$roundstoolow$kUMsbe306n21p9R.FRkW3IGn.S9NPN0x50YhH1xhLsPuWGsUSklZt58jaTfF4ZEQpyUNGc0dqbpBYYBaHHrsX."},
+}
+
+func TestSHA512Crypt(t *testing.T) {
+	for _, v := range sha512Vectors {
+		if got := sha512Crypt([]byte(v.key), []byte(v.salt), v.rounds); got != v.want {
+			t.Errorf("sha512Crypt(%q, %q, %d) = %q, want %q", v.key, v.salt, v.rounds, got, v.want)
+		}
+	}
+}
+
+func TestSHA512(t *testing.T) {
+	h, err := SHA512("Hello world!")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.Split(h, "$")
+	if len(parts) != 4 || parts[1] != "6" || len(parts[2]) != sha512SaltLength || len(parts[3]) != 86 {
+		t.Fatalf("SHA512 returned %q, which isn't a SHA-512 crypt hash", h)
+	}
+	if !IsHash(h) {
+		t.Errorf("IsHash(%q) = false", h)
+	}
+	if again := sha512Crypt([]byte("Hello world!"), []byte(parts[2]), sha512Rounds); again != h {
+		t.Errorf("hashing again with the salt of %q gives %q", h, again)
+	}
+}
@@ -0,0 +1,81 @@
+package shadow
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinLength is the length below which a password is considered
+// short.
+const MinLength = 8
+
+// common are passwords that are tried first by anyone guessing, the
+// ones an installer is likely to see included.
+var common = map[string]bool{
+	"123456":    true,
+	"12345678":  true,
+	"123456789": true,
+	"password":  true,
+	"qwerty":    true,
+	"letmein":   true,
+	"admin":     true,
+	"root":      true,
+	"toor":      true,
+	"void":      true,
+	"voidlinux": true,
+	"changeme":  true,
+}
+
+// Weaknesses returns what makes a password easy to guess, such as
+// being short or containing one of names, which are usually the
+// username and full name of the account.  Nothing is returned for a
+// good password.  The caller decides whether a weak one is allowed.
+func Weaknesses(password string, names ...string) []string {
+	out := []string{}
+	lower := strings.ToLower(password)
+	if common[lower] {
+		out = append(out, "it is a commonly used password")
+	}
+	if len([]rune(password)) < MinLength {
+		out = append(out, "it is shorter than 8 characters")
+	}
+	if containsName(lower, names) {
+		out = append(out, "it contains the name of the account")
+	}
+	if password != "" && classes(password) < 2 {
+		out = append(out, "it uses only one kind of character")
+	}
+	return out
+}
+
+// containsName reports whether any word of names of three letters
+// or more is in the password.
+func containsName(lower string, names []string) bool {
+	for _, name := range names {
+		for _, word := range strings.Fields(strings.ToLower(name)) {
+			if len(word) >= 3 && strings.Contains(lower, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// classes counts the kinds of character in s: lower case, upper
+// case, digits and everything else.
+func classes(s string) int {
+	var lower, upper, digit, other int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+	return lower + upper + digit + other
+}
@@ -0,0 +1,262 @@
+package sysinfo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Where the kernel and udev describe block devices.  These are
+// variables so that a fake tree can stand in for them.
+var (
+	sysBlock    = "/sys/class/block"
+	udevData    = "/run/udev/data"
+	procMounts  = "/proc/mounts"
+	procCmdline = "/proc/cmdline"
+	devByLabel  = "/dev/disk/by-label"
+	efiFirmware = "/sys/firmware/efi"
+)
+
+// liveMounts are where the live system mounts the medium it was
+// booted from.
+var liveMounts = []string{"/run/initramfs/live", "/run/initramfs/isoscan"}
+
+// Disk is a disk as the installer presents it, with what the kernel
+// and udev know about it in addition to what ghw found.
+type Disk struct {
+	Name      string
+	Path      string
+	SizeBytes uint64
+	Model     string
+	Transport string
+	Removable bool
+	ReadOnly  bool
+
+	// Live is set for the medium the live system was booted
+	// from, which must not be installed to.
+	Live bool
+
+	Partitions []Partition
+}
+
+// Partition is a partition of a Disk and what is on it.
+type Partition struct {
+	Name       string
+	Path       string
+	SizeBytes  uint64
+	FSType     string
+	Label      string
+	MountPoint string
+}
+
+func (d Disk) String() string {
+	out := fmt.Sprintf("%s %s", d.Path, HumanSize(d.SizeBytes))
+	if d.Model != "" {
+		out += " " + d.Model
+	}
+	if d.Transport != "" {
+		out += " (" + d.Transport + ")"
+	}
+	return out
+}
+
+func (p Partition) String() string {
+	out := fmt.Sprintf("%s %s", p.Path, HumanSize(p.SizeBytes))
+	if p.FSType != "" {
+		out += " " + p.FSType
+	}
+	if p.Label != "" {
+		out += " \"" + p.Label + "\""
+	}
+	if p.MountPoint != "" {
+		out += " on " + p.MountPoint
+	}
+	return out
+}
+
+// HumanSize formats a number of bytes with a binary unit.
+func HumanSize(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// Disks returns the disks of the machine, with the partitions on
+// them and the filesystems in those.
+func (s *System) Disks() []Disk {
+	disks := []Disk{}
+	if s == nil || s.Blk == nil {
+		return disks
+	}
+	live := liveDisks()
+	for _, bd := range s.Blk.Disks {
+		d := Disk{
+			Name:      bd.Name,
+			Path:      "/dev/" + bd.Name,
+			SizeBytes: bd.SizeBytes,
+			Model:     readSys(bd.Name, "device/model"),
+			Transport: transport(bd.Name),
+			Removable: readSys(bd.Name, "removable") == "1",
+			ReadOnly:  readSys(bd.Name, "ro") == "1",
+			Live:      live[bd.Name],
+
+			Partitions: []Partition{},
+		}
+		for _, bp := range bd.Partitions {
+			props := udevProperties(bp.Name)
+			p := Partition{
+				Name:       bp.Name,
+				Path:       "/dev/" + bp.Name,
+				SizeBytes:  bp.SizeBytes,
+				FSType:     props["ID_FS_TYPE"],
+				Label:      props["ID_FS_LABEL"],
+				MountPoint: bp.MountPoint,
+			}
+			if p.FSType == "" {
+				p.FSType = bp.Type
+			}
+			if p.Label == "" {
+				p.Label = bp.Label
+			}
+			d.Partitions = append(d.Partitions, p)
+		}
+		disks = append(disks, d)
+	}
+	return disks
+}
+
+// InstallableDisks returns the disks that can be installed to, which
+// leaves out the live medium, read only disks, and optical drives.
+func (s *System) InstallableDisks() []Disk {
+	out := []Disk{}
+	for _, d := range s.Disks() {
+		if d.Live || d.ReadOnly || d.SizeBytes == 0 || strings.HasPrefix(d.Name, "sr") {
+			continue
+		}
+		out = append(out, d)
+	}
+	return out
+}
+
+// EFI reports whether the machine was booted with UEFI, which
+// decides how it has to be partitioned.
+func (s *System) EFI() bool {
+	_, err := os.Stat(efiFirmware)
+	return err == nil
+}
+
+// readSys reads an attribute of a block device from sysfs.
+func readSys(name, attr string) string {
+	b, err := ioutil.ReadFile(filepath.Join(sysBlock, name, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// transport works out how a disk is attached from where it sits in
+// the device tree, as ghw doesn't say, or else from what udev
+// recorded about it.
+func transport(name string) string {
+	path, err := filepath.EvalSymlinks(filepath.Join(sysBlock, name))
+	if err == nil {
+		for _, t := range []string{"usb", "nvme", "virtio", "mmc", "ata"} {
+			if strings.Contains(path, "/"+t) {
+				if t == "ata" {
+					return "sata"
+				}
+				return t
+			}
+		}
+	}
+	bus := strings.ToLower(udevProperties(name)["ID_BUS"])
+	if bus == "ata" {
+		return "sata"
+	}
+	return bus
+}
+
+// udevProperties returns what udev recorded about a block device,
+// such as ID_FS_TYPE, which tells the filesystem of a partition that
+// isn't mounted.
+func udevProperties(name string) map[string]string {
+	props := make(map[string]string)
+	dev := readSys(name, "dev")
+	if dev == "" {
+		return props
+	}
+	b, err := ioutil.ReadFile(filepath.Join(udevData, "b"+dev))
+	if err != nil {
+		return props
+	}
+	for _, l := range strings.Split(string(b), "\n") {
+		if !strings.HasPrefix(l, "E:") {
+			continue
+		}
+		kv := strings.SplitN(strings.TrimPrefix(l, "E:"), "=", 2)
+		if len(kv) == 2 {
+			props[kv[0]] = kv[1]
+		}
+	}
+	return props
+}
+
+// diskOf returns the disk a block device is on, which is the device
+// itself unless it is a partition.
+func diskOf(dev string) string {
+	if resolved, err := filepath.EvalSymlinks(dev); err == nil {
+		dev = resolved
+	}
+	name := filepath.Base(dev)
+	if _, err := os.Stat(filepath.Join(sysBlock, name, "partition")); err == nil {
+		if path, err := filepath.EvalSymlinks(filepath.Join(sysBlock, name)); err == nil {
+			return filepath.Base(filepath.Dir(path))
+		}
+	}
+	return name
+}
+
+// liveDisks returns the disks the live system is running from, going
+// by where the medium is mounted and by the label the kernel was told
+// to look for.
+func liveDisks() map[string]bool {
+	live := make(map[string]bool)
+	if b, err := ioutil.ReadFile(procMounts); err == nil {
+		for _, l := range strings.Split(string(b), "\n") {
+			fields := strings.Fields(l)
+			if len(fields) < 3 || !strings.HasPrefix(fields[0], "/dev/") {
+				continue
+			}
+			for _, m := range liveMounts {
+				if fields[1] == m || fields[2] == "iso9660" {
+					live[diskOf(fields[0])] = true
+				}
+			}
+		}
+	}
+
+	if b, err := ioutil.ReadFile(procCmdline); err == nil {
+		for _, arg := range strings.Fields(string(b)) {
+			if !strings.HasPrefix(arg, "root=live:") {
+				continue
+			}
+			root := strings.TrimPrefix(arg, "root=live:")
+			for _, prefix := range []string{"CDLABEL=", "LABEL="} {
+				if strings.HasPrefix(root, prefix) {
+					label := strings.TrimPrefix(root, prefix)
+					live[diskOf(filepath.Join(devByLabel, label))] = true
+				}
+			}
+		}
+	}
+	return live
+}
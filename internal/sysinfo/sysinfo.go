@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"log"
+	"sort"
 
 	"github.com/jaypipes/ghw"
 )
@@ -39,6 +40,40 @@ func (s *System) String() string {
 	return strings.Join(out, "\n")
 }
 
+// Interfaces returns the names of the physical network interfaces,
+// which are the ones worth configuring on the installed system.
+func (s *System) Interfaces() []string {
+	names := []string{}
+	if s == nil || s.Net == nil {
+		return names
+	}
+	for _, n := range s.Net.NICs {
+		if !n.IsVirtual {
+			names = append(names, n.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Report is what the frontends that serve another program tell it
+// about the machine.
+type Report struct {
+	Summary    string
+	Disks      []Disk
+	Interfaces []string
+}
+
+// Report describes the machine, with the disks that can be installed
+// to as the interactive frontends offer them.
+func (s *System) Report() Report {
+	r := Report{Disks: s.InstallableDisks(), Interfaces: s.Interfaces()}
+	if s != nil {
+		r.Summary = s.String()
+	}
+	return r
+}
+
 // DiscoverHardware fetches system info
 func DiscoverHardware() *System {
 	sys := new(System)
@@ -0,0 +1,189 @@
+// Package timezone reads the zoneinfo database of a system so that
+// time zones can be validated, browsed and searched.
+package timezone
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Dir is where the zoneinfo database lives, relative to the root of
+// a system.
+const Dir = "usr/share/zoneinfo"
+
+// ErrUnknownZone is returned if a zone is not in the database.
+var ErrUnknownZone = errors.New("time zone is not known")
+
+// skip are the parts of the database that aren't zones anyone should
+// pick, the posix and right trees duplicate everything else.
+var skip = map[string]bool{
+	"posix":      true,
+	"right":      true,
+	"posixrules": true,
+	"localtime":  true,
+	"Factory":    true,
+}
+
+// Available returns true if the system at root has a zoneinfo
+// database.
+func Available(root string) bool {
+	stat, err := os.Stat(filepath.Join(root, Dir))
+	return err == nil && stat.IsDir()
+}
+
+// List returns the names of all the zones in the database of the
+// system at root, sorted.
+func List(root string) ([]string, error) {
+	dir := filepath.Join(root, Dir)
+	zones := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path, dir+"/")
+		if skip[name] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() || !isZone(path) {
+			return nil
+		}
+		zones = append(zones, name)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(zones)
+	return zones, nil
+}
+
+// isZone checks for the magic number of a compiled zone, which tells
+// them apart from the tables and other files in the database.
+func isZone(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(f, magic); err != nil {
+		return false
+	}
+	return bytes.Equal(magic, []byte("TZif"))
+}
+
+// Canonicalize returns the name of the zone in zones that the user
+// most likely meant by name, ignoring case, surrounding space, and
+// spaces in place of underscores.
+func Canonicalize(zones []string, name string) (string, error) {
+	name = strings.TrimSpace(name)
+	for _, z := range zones {
+		if z == name {
+			return z, nil
+		}
+	}
+	want := normalize(name)
+	for _, z := range zones {
+		if normalize(z) == want {
+			return z, nil
+		}
+	}
+	return "", ErrUnknownZone
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(s), " ", "_", -1))
+}
+
+// Regions returns the top level regions of zones, such as Europe or
+// America.  Zones that aren't in a region, such as UTC, are not
+// included, use Cities with an empty region to find them.
+func Regions(zones []string) []string {
+	seen := make(map[string]bool)
+	regions := []string{}
+	for _, z := range zones {
+		parts := strings.SplitN(z, "/", 2)
+		if len(parts) == 2 && !seen[parts[0]] {
+			seen[parts[0]] = true
+			regions = append(regions, parts[0])
+		}
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// Cities returns the zones within a region with the region removed,
+// for example "Argentina/Salta" for the region America.
+func Cities(zones []string, region string) []string {
+	cities := []string{}
+	for _, z := range zones {
+		if region == "" {
+			if !strings.Contains(z, "/") {
+				cities = append(cities, z)
+			}
+			continue
+		}
+		if strings.HasPrefix(z, region+"/") {
+			cities = append(cities, strings.TrimPrefix(z, region+"/"))
+		}
+	}
+	return cities
+}
+
+// Search returns the zones that match query, best matches first.
+// Zones containing the query rank above zones that only contain its
+// letters in order, and shorter names rank above longer ones.
+func Search(zones []string, query string) []string {
+	q := strings.Replace(normalize(query), "_", "", -1)
+	if q == "" {
+		return nil
+	}
+
+	type match struct {
+		zone  string
+		score int
+	}
+	matches := []match{}
+	for _, z := range zones {
+		n := strings.Replace(normalize(z), "_", "", -1)
+		switch {
+		case strings.HasSuffix(n, "/"+q) || n == q:
+			matches = append(matches, match{z, 0})
+		case strings.Contains(n, q):
+			matches = append(matches, match{z, 1})
+		case subsequence(n, q):
+			matches = append(matches, match{z, 2})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].score != matches[b].score {
+			return matches[a].score < matches[b].score
+		}
+		return len(matches[a].zone) < len(matches[b].zone)
+	})
+
+	out := make([]string, len(matches))
+	for i, m := range matches {
+		out[i] = m.zone
+	}
+	return out
+}
+
+// subsequence returns true if all the bytes of sub appear in s in
+// order.
+func subsequence(s, sub string) bool {
+	for i := 0; i < len(s) && len(sub) > 0; i++ {
+		if s[i] == sub[0] {
+			sub = sub[1:]
+		}
+	}
+	return len(sub) == 0
+}